@@ -1,104 +1,157 @@
 package main
 
 import (
-	"encoding/base64"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
-	"strings"
 	"time"
 
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
 	"github.com/andatoshiki/wakafetch/wakafetch/types"
 )
 
-func fetchSummary(apiKey, apiURL string, days int, timeoutSeconds int) (*types.SummaryResponse, error) {
-	apiURL = strings.TrimSuffix(apiURL, "/")
+// newClient returns the API client of apiURL and apiKey with --timeout per request.
+func newClient(apiKey, apiURL string, timeoutSeconds int) *client.Client {
+	c := client.New(apiURL, apiKey)
+	if timeoutSeconds > 0 {
+		c.HTTPClient.Timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	c.UserAgent = "wakafetch/" + Version
+	return c
+}
+
+// errInterrupted is the error of fetches cancelled by Ctrl-C (or SIGTERM).
+var errInterrupted = errors.New("Interrupted")
+
+// withDeadline returns ctx limited to --deadline, if set: the time limit of all the
+// requests of a run, unlike --timeout which is per request.
+func withDeadline(ctx context.Context, config Config) (context.Context, context.CancelFunc) {
+	if *config.deadlineFlag > 0 {
+		return context.WithTimeout(ctx, *config.deadlineFlag)
+	}
+	return context.WithCancel(ctx)
+}
+
+// fetchError wraps the error of a fetch of what ("stats"); an interrupted run and an
+// exceeded --deadline say so instead of how the request failed.
+func fetchError(what string, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return errors.New("Stopped: --deadline exceeded before all requests finished")
+	}
+	return fmt.Errorf("failed to fetch %s: %w", what, err)
+}
+
+func fetchSummary(ctx context.Context, apiKey, apiURL string, days int, timeoutSeconds int) (*types.SummaryResponse, error) {
 	today := time.Now()
-	todayDate := today.Format("2006-01-02")
 	startDate := today.AddDate(0, 0, -days+1).Format("2006-01-02")
-	requestURL := fmt.Sprintf("%s/v1/users/current/summaries?start=%s&end=%s", apiURL, startDate, todayDate)
-	if strings.HasSuffix(apiURL, "/v1") {
-		requestURL = fmt.Sprintf("%s/users/current/summaries?start=%s&end=%s", apiURL, startDate, todayDate)
-	}
-	response, err := fetchApi[types.SummaryResponse](apiKey, requestURL, timeoutSeconds)
+	return fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, today.Format("2006-01-02"), nil, timeoutSeconds)
+}
+
+func fetchSummaryWithDates(ctx context.Context, apiKey, apiURL, startDate, endDate string, opts *client.SummariesOptions, timeoutSeconds int) (*types.SummaryResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Summaries(ctx, startDate, endDate, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch stats: %w", err)
+		return nil, fetchError("stats", err)
 	}
 	return response, nil
 }
 
-func fetchSummaryWithDates(apiKey, apiURL, startDate, endDate string, timeoutSeconds int) (*types.SummaryResponse, error) {
-	apiURL = strings.TrimSuffix(apiURL, "/")
-	requestURL := fmt.Sprintf("%s/v1/users/current/summaries?start=%s&end=%s", apiURL, startDate, endDate)
-	if strings.HasSuffix(apiURL, "/v1") {
-		requestURL = fmt.Sprintf("%s/users/current/summaries?start=%s&end=%s", apiURL, startDate, endDate)
+// fetchHeartbeats fetches the heartbeats of one day (YYYY-MM-DD).
+func fetchHeartbeats(ctx context.Context, apiKey, apiURL, date string, timeoutSeconds int) (*types.HeartbeatsResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Heartbeats(ctx, date)
+	if err != nil {
+		return nil, fetchError("heartbeats", err)
 	}
-	response, err := fetchApi[types.SummaryResponse](apiKey, requestURL, timeoutSeconds)
+	return response, nil
+}
+
+func fetchStats(ctx context.Context, apiKey, apiURL, rangeStr string, timeoutSeconds int) (*types.StatsResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Stats(ctx, rangeStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch stats: %w", err)
+		return nil, fetchError("stats", err)
 	}
 	return response, nil
 }
 
-func fetchStats(apiKey, apiURL, rangeStr string, timeoutSeconds int) (*types.StatsResponse, error) {
-	apiURL = strings.TrimSuffix(apiURL, "/")
-	requestURL := fmt.Sprintf("%s/v1/users/current/stats/%s", apiURL, rangeStr)
-	if strings.HasSuffix(apiURL, "/v1") {
-		requestURL = fmt.Sprintf("%s/users/current/stats/%s", apiURL, rangeStr)
+// fetchUserAgents fetches the editor plugins that sent heartbeats (WakaTime only).
+func fetchUserAgents(ctx context.Context, apiKey, apiURL string, timeoutSeconds int) (*types.UserAgentsResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).UserAgents(ctx)
+	if err != nil {
+		return nil, fetchError("user agents", err)
 	}
-	// fmt.Println(requestURL)
-	response, err := fetchApi[types.StatsResponse](apiKey, requestURL, timeoutSeconds)
+	return response, nil
+}
+
+// fetchMachineNames fetches the machines that sent heartbeats (WakaTime only).
+func fetchMachineNames(ctx context.Context, apiKey, apiURL string, timeoutSeconds int) (*types.MachineNamesResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).MachineNames(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch stats: %w", err)
+		return nil, fetchError("machine names", err)
 	}
 	return response, nil
 }
 
-func fetchApi[T any](apiKey, requestURL string, timeoutSeconds int) (*T, error) {
-	if timeoutSeconds <= 0 {
-		timeoutSeconds = 10
+// fetchCurrentUser fetches the account of the API key.
+func fetchCurrentUser(ctx context.Context, apiKey, apiURL string, timeoutSeconds int) (*types.UserResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).CurrentUser(ctx)
+	if err != nil {
+		return nil, fetchError("user", err)
 	}
-	timeout := time.Duration(timeoutSeconds) * time.Second
-	req, err := http.NewRequest("GET", requestURL, nil)
+	return response, nil
+}
+
+// fetchAllTimeSinceToday fetches the total time since the account was created.
+func fetchAllTimeSinceToday(ctx context.Context, apiKey, apiURL string, timeoutSeconds int) (*types.AllTimeSinceTodayResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).AllTimeSinceToday(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fetchError("all time", err)
 	}
+	return response, nil
+}
 
-	encodedKey := base64.StdEncoding.EncodeToString([]byte(apiKey))
-	req.Header.Set("Authorization", "Basic "+encodedKey)
+// fetchLeaders fetches a page of the public leaderboard, or of a private one with board.
+func fetchLeaders(ctx context.Context, apiKey, apiURL string, opts *client.LeadersOptions, timeoutSeconds int) (*types.LeadersResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Leaders(ctx, opts)
+	if err != nil {
+		return nil, fetchError("leaderboard", err)
+	}
+	return response, nil
+}
 
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
+// fetchOrgs fetches the organizations of the user (WakaTime only).
+func fetchOrgs(ctx context.Context, apiKey, apiURL string, timeoutSeconds int) (*types.OrgsResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Orgs(ctx)
 	if err != nil {
-		if ne, ok := err.(interface{ Timeout() bool }); ok && ne.Timeout() {
-			return nil, fmt.Errorf("Request timed out after %s while contacting server", timeout)
-		}
-		return nil, fmt.Errorf("Unable to reach server. Check your internet connection")
+		return nil, fetchError("organizations", err)
 	}
+	return response, nil
+}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		switch resp.StatusCode {
-		case http.StatusUnauthorized:
-			return nil, fmt.Errorf("Authentication failed (401). Check your API key")
-		case http.StatusForbidden:
-			return nil, fmt.Errorf("Access forbidden (403). Your API key might not have permission")
-		case http.StatusNotFound:
-			return nil, fmt.Errorf("Endpoint not found (404). Verify the API URL")
-		case http.StatusTooManyRequests:
-			return nil, fmt.Errorf("Rate limit exceeded (429). Please try again later")
-		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
-			return nil, fmt.Errorf("Server unavailable (%s). Please try again later", resp.Status)
-		default:
-			return nil, fmt.Errorf("Api request failed: %s", resp.Status)
-		}
+// fetchDashboards fetches the dashboards of an organization.
+func fetchDashboards(ctx context.Context, apiKey, apiURL, org string, timeoutSeconds int) (*types.DashboardsResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).Dashboards(ctx, org)
+	if err != nil {
+		return nil, fetchError("dashboards", err)
 	}
+	return response, nil
+}
 
-	var apiResponse T
-	if err = json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("Invalid response from server (failed to decode JSON)")
+// fetchDashboardMembers fetches the members of an organization's dashboard.
+func fetchDashboardMembers(ctx context.Context, apiKey, apiURL, org, dashboard string, timeoutSeconds int) (*types.DashboardMembersResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).DashboardMembers(ctx, org, dashboard)
+	if err != nil {
+		return nil, fetchError("dashboard members", err)
 	}
+	return response, nil
+}
 
-	return &apiResponse, nil
+// fetchMemberSummary fetches /summaries of a member of an organization's dashboard.
+func fetchMemberSummary(ctx context.Context, apiKey, apiURL, org, dashboard, member, startDate, endDate string, timeoutSeconds int) (*types.SummaryResponse, error) {
+	response, err := newClient(apiKey, apiURL, timeoutSeconds).MemberSummaries(ctx, org, dashboard, member, startDate, endDate, nil)
+	if err != nil {
+		return nil, fetchError("stats", err)
+	}
+	return response, nil
 }
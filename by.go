@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// byDimensions are the values of --by.
+var byDimensions = []string{"branch", "dependency"}
+
+// maxGroupedProjects caps the projects of --by branch: each one is a request.
+const maxGroupedProjects = 10
+
+// maxHeartbeatDays caps the range of --by dependency: each day is a request.
+const maxHeartbeatDays = 31
+
+// groupedBreakdown is the --json output of --by.
+type groupedBreakdown struct {
+	Range  string     `json:"range"`
+	Start  string     `json:"start"`
+	End    string     `json:"end"`
+	By     string     `json:"by"`
+	Groups []ui.Group `json:"groups"`
+}
+
+// runBy prints the --by breakdown of --range (or --days) from the Summary API.
+func runBy(ctx context.Context, config Config, apiKey, apiURL string) {
+	startDate, endDate, heading := summaryDates(config, "today")
+
+	var groups []ui.Group
+	var groupTitle, card string
+	var err error
+	switch *config.byFlag {
+	case "branch":
+		groupTitle, card = "Projects", "branches"
+		groups, err = branchGroups(ctx, config, apiKey, apiURL, startDate, endDate, heading)
+	case "dependency":
+		groupTitle, card = "Languages", "dependencies"
+		groups, err = dependencyGroups(ctx, config, apiKey, apiURL, startDate, endDate)
+	}
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+
+	if *config.jsonFlag {
+		outputJSON(groupedBreakdown{Range: heading, Start: startDate, End: endDate, By: *config.byFlag, Groups: groups})
+		return
+	}
+	display := func() { ui.DisplayGroups(groups, heading, groupTitle, card) }
+	switch {
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
+
+// branchGroups groups the branches under their projects. The API only has branches per
+// project (with the project parameter), so each of the top projects is fetched on its own.
+// When none of them has branches but the whole range does (some Wakapi versions),
+// those are returned as a single "All projects" group.
+func branchGroups(ctx context.Context, config Config, apiKey, apiURL, startDate, endDate, heading string) ([]ui.Group, error) {
+	data, err := fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, nil, *config.timeoutFlag)
+	if err != nil {
+		return nil, err
+	}
+	var groups []ui.Group
+	for i, project := range ui.NewSummaryView(data, heading).Projects {
+		if i == maxGroupedProjects || project.Seconds < ui.Opts.MinSeconds {
+			break
+		}
+		projectData, err := fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, &client.SummariesOptions{Project: project.Name}, *config.timeoutFlag)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err // interrupted or past --deadline, not the project's fault
+			}
+			return nil, fmt.Errorf("%s: %w", project.Name, err)
+		}
+		if g := ui.NewGroup(project.Name, "branches", projectData.Data); len(g.Items) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		if g := ui.NewGroup("All projects", "branches", data.Data); len(g.Items) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
+
+// dependencyGroups groups the dependencies under their languages (only --language's, if given).
+// Summaries don't tie dependencies to languages, so they're timed from the heartbeats of each day.
+func dependencyGroups(ctx context.Context, config Config, apiKey, apiURL, startDate, endDate string) ([]ui.Group, error) {
+	start, _ := time.Parse("2006-01-02", startDate)
+	end, _ := time.Parse("2006-01-02", endDate)
+	if days := int(end.Sub(start).Hours()/24) + 1; days > maxHeartbeatDays {
+		return nil, fmt.Errorf("--by dependency reads the heartbeats of every day: use a range of at most %d days (e.g. -r 30d)", maxHeartbeatDays)
+	}
+	var heartbeats []types.Heartbeat
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		data, err := fetchHeartbeats(ctx, apiKey, apiURL, day.Format("2006-01-02"), *config.timeoutFlag)
+		if err != nil {
+			return nil, err
+		}
+		heartbeats = append(heartbeats, data.Data...)
+	}
+	return ui.DependencyGroups(heartbeats, *config.languageFlag), nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import "strings"
+
+// Backend is the kind of server behind an API URL.
+type Backend int
+
+const (
+	// WakaTime is the official API at wakatime.com.
+	WakaTime Backend = iota
+	// Wakapi is Wakapi or another self-hosted server with a WakaTime-compatible API.
+	Wakapi
+)
+
+func (b Backend) String() string {
+	if b == WakaTime {
+		return "WakaTime"
+	}
+	return "Wakapi"
+}
+
+// Capabilities are the features that differ between the backends.
+type Capabilities struct {
+	// StatsToday is whether /stats has the today and yesterday ranges.
+	// Without it, use Summaries for a single day.
+	StatsToday bool
+	// Durations is whether /durations is available.
+	Durations bool
+	// Goals is whether /goals is available.
+	Goals bool
+	// SummaryBranches is whether /summaries has branches without a project filter.
+	SummaryBranches bool
+	// UserAgents is whether /user_agents and /machine_names are available. Without them,
+	// the UserAgentID and MachineNameID of heartbeats are the user agent and machine name.
+	UserAgents bool
+	// PrivateLeaderboards is whether /users/current/leaderboards is available.
+	PrivateLeaderboards bool
+	// Orgs is whether /users/current/orgs and the dashboards of organizations are available.
+	Orgs bool
+}
+
+// DetectBackend tells the backend of an API URL: WakaTime for wakatime.com, Wakapi for
+// anything else.
+func DetectBackend(apiURL string) Backend {
+	if strings.Contains(apiURL, "wakatime.com") {
+		return WakaTime
+	}
+	return Wakapi
+}
+
+// Capabilities returns the features of the backend.
+func (b Backend) Capabilities() Capabilities {
+	if b == WakaTime {
+		return Capabilities{Durations: true, Goals: true, UserAgents: true, PrivateLeaderboards: true, Orgs: true}
+	}
+	return Capabilities{StatsToday: true, SummaryBranches: true}
+}
+
+// NormalizeURL returns the API root of an API URL. WakaTime's is kept as is (it expects
+// /v1/... paths). Wakapi and other self-hosted servers are pointed at their
+// WakaTime-compatible prefix, <base>/api/compat/wakatime.
+func NormalizeURL(apiURL string) string {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	if DetectBackend(apiURL) == WakaTime {
+		return apiURL
+	}
+	switch {
+	case strings.HasSuffix(apiURL, "/api/compat/wakatime"):
+		// Already normalized
+	case strings.HasSuffix(apiURL, "/api"):
+		apiURL += "/compat/wakatime"
+	default:
+		apiURL += "/api/compat/wakatime"
+	}
+	return apiURL
+}
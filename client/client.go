@@ -0,0 +1,293 @@
+// Package client is a client for the WakaTime API and WakaTime-compatible servers
+// (Wakapi and other self-hosted instances), as used by wakafetch.
+//
+//	c := client.New("https://wakatime.com/api", apiKey)
+//	stats, err := c.Stats(ctx, "last_7_days")
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// DefaultTimeout is the timeout of the http.Client made by New.
+const DefaultTimeout = 10 * time.Second
+
+// Client talks to one API server with one API key.
+type Client struct {
+	// BaseURL is the API root, e.g. https://wakatime.com/api or
+	// https://wakapi.dev/api/compat/wakatime (see NormalizeURL). Paths go under /v1,
+	// unless BaseURL already ends in /v1.
+	BaseURL string
+	// APIKey is sent as HTTP basic auth.
+	APIKey string
+	// HTTPClient does the requests; its Timeout is the timeout of each request.
+	HTTPClient *http.Client
+	// UserAgent, when not empty, is sent with every request.
+	UserAgent string
+}
+
+// New returns a client for baseURL (normalized with NormalizeURL) and apiKey,
+// with a DefaultTimeout per request.
+func New(baseURL, apiKey string) *Client {
+	return &Client{
+		BaseURL:    NormalizeURL(baseURL),
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Backend is the server kind of the client's BaseURL.
+func (c *Client) Backend() Backend {
+	return DetectBackend(c.BaseURL)
+}
+
+// Capabilities are the features of the client's backend.
+func (c *Client) Capabilities() Capabilities {
+	return c.Backend().Capabilities()
+}
+
+// Stats fetches /stats of a range: last_7_days, last_30_days, last_6_months, last_year,
+// all_time, or a year ("2024"); Wakapi also has today and yesterday
+// (see Capabilities.StatsToday).
+//
+// WakaTime answers 202 Accepted with partial stats while it computes them: that isn't an
+// error, the response's StillComputing is true and PercentCalculated tells how far it got.
+func (c *Client) Stats(ctx context.Context, rangeStr string) (*types.StatsResponse, error) {
+	response, status, err := getStatus[types.StatsResponse](ctx, c, "/users/current/stats/"+url.PathEscape(rangeStr), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted && response.Data.IsUpToDate == nil {
+		upToDate := false
+		response.Data.IsUpToDate = &upToDate
+	}
+	return response, nil
+}
+
+// SummariesOptions are the optional filters of Summaries.
+type SummariesOptions struct {
+	Project  string   // only this project's time
+	Branches []string // with Project, only these branches
+	Timezone string   // IANA timezone of the days, instead of the user's
+}
+
+// Summaries fetches /summaries from start to end (YYYY-MM-DD, both included), one entry per day.
+// opts may be nil.
+func (c *Client) Summaries(ctx context.Context, start, end string, opts *SummariesOptions) (*types.SummaryResponse, error) {
+	query := url.Values{"start": {start}, "end": {end}}
+	if opts != nil {
+		setQuery(query, "project", opts.Project)
+		setQuery(query, "branches", strings.Join(opts.Branches, ","))
+		setQuery(query, "timezone", opts.Timezone)
+	}
+	return get[types.SummaryResponse](ctx, c, "/users/current/summaries", query)
+}
+
+// DurationsOptions are the optional filters of Durations.
+type DurationsOptions struct {
+	Project  string   // only this project's durations
+	Branches []string // with Project, only these branches
+	SliceBy  string   // split by entity, language, dependencies, os, editor, category or machine
+	Timezone string   // IANA timezone of the day, instead of the user's
+}
+
+// Durations fetches /durations of one day (YYYY-MM-DD): the user's coding sessions.
+// opts may be nil. WakaTime only (see Capabilities.Durations).
+func (c *Client) Durations(ctx context.Context, date string, opts *DurationsOptions) (*types.DurationsResponse, error) {
+	query := url.Values{"date": {date}}
+	if opts != nil {
+		setQuery(query, "project", opts.Project)
+		setQuery(query, "branches", strings.Join(opts.Branches, ","))
+		setQuery(query, "slice_by", opts.SliceBy)
+		setQuery(query, "timezone", opts.Timezone)
+	}
+	return get[types.DurationsResponse](ctx, c, "/users/current/durations", query)
+}
+
+// Heartbeats fetches the heartbeats of one day (YYYY-MM-DD).
+func (c *Client) Heartbeats(ctx context.Context, date string) (*types.HeartbeatsResponse, error) {
+	return get[types.HeartbeatsResponse](ctx, c, "/users/current/heartbeats", url.Values{"date": {date}})
+}
+
+// UserAgents fetches the editor plugins that sent heartbeats, to name the UserAgentID of
+// heartbeats. WakaTime only (see Capabilities.UserAgents).
+func (c *Client) UserAgents(ctx context.Context) (*types.UserAgentsResponse, error) {
+	return get[types.UserAgentsResponse](ctx, c, "/users/current/user_agents", nil)
+}
+
+// MachineNames fetches the machines that sent heartbeats, to name the MachineNameID of
+// heartbeats. WakaTime only (see Capabilities.UserAgents).
+func (c *Client) MachineNames(ctx context.Context) (*types.MachineNamesResponse, error) {
+	return get[types.MachineNamesResponse](ctx, c, "/users/current/machine_names", nil)
+}
+
+// CurrentUser fetches the account of the API key: its username, timezone, plan, ...
+func (c *Client) CurrentUser(ctx context.Context) (*types.UserResponse, error) {
+	return get[types.UserResponse](ctx, c, "/users/current", nil)
+}
+
+// AllTimeSinceToday fetches the total time since the account was created. Like Stats,
+// WakaTime answers 202 Accepted while it computes it, with IsUpToDate false.
+func (c *Client) AllTimeSinceToday(ctx context.Context) (*types.AllTimeSinceTodayResponse, error) {
+	return get[types.AllTimeSinceTodayResponse](ctx, c, "/users/current/all_time_since_today", nil)
+}
+
+// LeadersOptions select the leaderboard and page of Leaders.
+type LeadersOptions struct {
+	Board    string // ID of a private leaderboard, "" for the public one
+	Language string // only this language's ranking
+	Page     int    // 1-based, 0 for the first page
+}
+
+// Leaders fetches a page of a leaderboard: the public one (/leaders) or a private one
+// (/users/current/leaderboards/:board, WakaTime only, see Capabilities.PrivateLeaderboards).
+// opts may be nil.
+func (c *Client) Leaders(ctx context.Context, opts *LeadersOptions) (*types.LeadersResponse, error) {
+	path, query := "/leaders", url.Values{}
+	if opts != nil {
+		if opts.Board != "" {
+			path = "/users/current/leaderboards/" + url.PathEscape(opts.Board)
+		}
+		setQuery(query, "language", opts.Language)
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+	}
+	return get[types.LeadersResponse](ctx, c, path, query)
+}
+
+// Orgs fetches the organizations the user is in. WakaTime only (see Capabilities.Orgs).
+func (c *Client) Orgs(ctx context.Context) (*types.OrgsResponse, error) {
+	return get[types.OrgsResponse](ctx, c, "/users/current/orgs", nil)
+}
+
+// Dashboards fetches the dashboards of an organization (by ID) the user can see.
+func (c *Client) Dashboards(ctx context.Context, org string) (*types.DashboardsResponse, error) {
+	return get[types.DashboardsResponse](ctx, c, "/users/current/orgs/"+url.PathEscape(org)+"/dashboards", nil)
+}
+
+// DashboardMembers fetches the members of an organization's dashboard (by IDs).
+func (c *Client) DashboardMembers(ctx context.Context, org, dashboard string) (*types.DashboardMembersResponse, error) {
+	return get[types.DashboardMembersResponse](ctx, c, dashboardPath(org, dashboard)+"/members", nil)
+}
+
+// MemberSummaries is Summaries for a member of an organization's dashboard (by IDs).
+// opts may be nil; its Timezone is ignored, the dashboard's applies.
+func (c *Client) MemberSummaries(ctx context.Context, org, dashboard, member, start, end string, opts *SummariesOptions) (*types.SummaryResponse, error) {
+	query := url.Values{"start": {start}, "end": {end}}
+	if opts != nil {
+		setQuery(query, "project", opts.Project)
+		setQuery(query, "branches", strings.Join(opts.Branches, ","))
+	}
+	return get[types.SummaryResponse](ctx, c, dashboardPath(org, dashboard)+"/members/"+url.PathEscape(member)+"/summaries", query)
+}
+
+func dashboardPath(org, dashboard string) string {
+	return "/users/current/orgs/" + url.PathEscape(org) + "/dashboards/" + url.PathEscape(dashboard)
+}
+
+// Goals fetches the user's goals with their recent progress. WakaTime only (see Capabilities.Goals).
+func (c *Client) Goals(ctx context.Context) (*types.GoalsResponse, error) {
+	return get[types.GoalsResponse](ctx, c, "/users/current/goals", nil)
+}
+
+// endpoint returns the URL of an API path ("/users/current/...").
+func (c *Client) endpoint(path string, query url.Values) string {
+	base := strings.TrimSuffix(c.BaseURL, "/")
+	if !strings.HasSuffix(base, "/v1") {
+		base += "/v1"
+	}
+	if len(query) > 0 {
+		return base + path + "?" + query.Encode()
+	}
+	return base + path
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// APIError is a response with a status other than 200 OK and 202 Accepted. Its message says
+// what the status usually means for wakafetch's users.
+type APIError struct {
+	StatusCode int
+	Status     string // e.g. "404 Not Found"
+}
+
+func (e *APIError) Error() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "Authentication failed (401). Check your API key"
+	case http.StatusForbidden:
+		return "Access forbidden (403). Your API key might not have permission"
+	case http.StatusNotFound:
+		return "Endpoint not found (404). Verify the API URL"
+	case http.StatusTooManyRequests:
+		return "Rate limit exceeded (429). Please try again later"
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return fmt.Sprintf("Server unavailable (%s). Please try again later", e.Status)
+	default:
+		return fmt.Sprintf("Api request failed: %s", e.Status)
+	}
+}
+
+// get does a GET of an API path and decodes its JSON into a T.
+func get[T any](ctx context.Context, c *Client, path string, query url.Values) (*T, error) {
+	response, _, err := getStatus[T](ctx, c, path, query)
+	return response, err
+}
+
+// getStatus is get, also returning the response's status code: 200 OK, or 202 Accepted
+// when WakaTime is still computing the data.
+func getStatus[T any](ctx context.Context, c *Client, path string, query url.Values) (*T, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint(path, query), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.APIKey)))
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, ctxErr
+		}
+		if ne, ok := err.(interface{ Timeout() bool }); ok && ne.Timeout() {
+			return nil, 0, fmt.Errorf("Request timed out after %s while contacting server", httpClient.Timeout)
+		}
+		return nil, 0, errors.New("Unable to reach server. Check your internet connection")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, resp.StatusCode, &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	var response T
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, ctxErr
+		}
+		return nil, resp.StatusCode, errors.New("Invalid response from server (failed to decode JSON)")
+	}
+	return &response, resp.StatusCode, nil
+}
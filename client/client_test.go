@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		base, path string
+		query      url.Values
+		want       string
+	}{
+		{"https://wakatime.com/api", "/users/current", nil, "https://wakatime.com/api/v1/users/current"},
+		{"https://wakatime.com/api/", "/users/current", nil, "https://wakatime.com/api/v1/users/current"},
+		{"https://wakatime.com/api/v1", "/users/current", nil, "https://wakatime.com/api/v1/users/current"},
+		{"https://wakatime.com/api/v1/", "/users/current", nil, "https://wakatime.com/api/v1/users/current"},
+		{"https://wakapi.dev/api/compat/wakatime", "/users/current/stats/last_7_days", nil, "https://wakapi.dev/api/compat/wakatime/v1/users/current/stats/last_7_days"},
+		{"https://wakatime.com/api", "/leaders", url.Values{"page": {"2"}, "language": {"C++"}}, "https://wakatime.com/api/v1/leaders?language=C%2B%2B&page=2"},
+	}
+	for _, tt := range tests {
+		c := &Client{BaseURL: tt.base}
+		if got := c.endpoint(tt.path, tt.query); got != tt.want {
+			t.Errorf("endpoint(%q, %q) with BaseURL %q = %q, want %q", tt.path, tt.query, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := map[string]string{
+		"https://wakatime.com/api":                "https://wakatime.com/api",
+		"https://wakatime.com/api/":               "https://wakatime.com/api",
+		"https://wakatime.com/api/v1":             "https://wakatime.com/api/v1",
+		"https://wakapi.dev":                      "https://wakapi.dev/api/compat/wakatime",
+		"https://wakapi.dev/":                     "https://wakapi.dev/api/compat/wakatime",
+		"https://wakapi.dev/api":                  "https://wakapi.dev/api/compat/wakatime",
+		"https://wakapi.dev/api/compat/wakatime":  "https://wakapi.dev/api/compat/wakatime",
+		"https://wakapi.dev/api/compat/wakatime/": "https://wakapi.dev/api/compat/wakatime",
+		"http://localhost:3000/sub/api":           "http://localhost:3000/sub/api/compat/wakatime",
+	}
+	for in, want := range tests {
+		if got := NormalizeURL(in); got != want {
+			t.Errorf("NormalizeURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetectBackend(t *testing.T) {
+	tests := map[string]Backend{
+		"https://wakatime.com/api":     WakaTime,
+		"https://api.wakatime.com/api": WakaTime,
+		"https://wakapi.dev/api":       Wakapi,
+		"http://localhost:3000":        Wakapi,
+		"":                             Wakapi,
+	}
+	for in, want := range tests {
+		if got := DetectBackend(in); got != want {
+			t.Errorf("DetectBackend(%q) = %s, want %s", in, got, want)
+		}
+	}
+	if !WakaTime.Capabilities().Durations || WakaTime.Capabilities().StatsToday {
+		t.Errorf("WakaTime capabilities = %+v", WakaTime.Capabilities())
+	}
+	if Wakapi.Capabilities().Orgs || !Wakapi.Capabilities().StatsToday {
+		t.Errorf("Wakapi capabilities = %+v", Wakapi.Capabilities())
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		code   int
+		status string
+		want   string
+	}{
+		{401, "401 Unauthorized", "Authentication failed (401). Check your API key"},
+		{403, "403 Forbidden", "Access forbidden (403). Your API key might not have permission"},
+		{404, "404 Not Found", "Endpoint not found (404). Verify the API URL"},
+		{429, "429 Too Many Requests", "Rate limit exceeded (429). Please try again later"},
+		{502, "502 Bad Gateway", "Server unavailable (502 Bad Gateway). Please try again later"},
+		{503, "503 Service Unavailable", "Server unavailable (503 Service Unavailable). Please try again later"},
+		{500, "500 Internal Server Error", "Api request failed: 500 Internal Server Error"},
+	}
+	for _, tt := range tests {
+		if got := (&APIError{StatusCode: tt.code, Status: tt.status}).Error(); got != tt.want {
+			t.Errorf("APIError(%d).Error() = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+// testServer answers every request with status and body, recording the last request.
+func testServer(t *testing.T, status int, body string) (*Client, *http.Request) {
+	t.Helper()
+	last := &http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = *r.Clone(context.Background())
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return New(server.URL, "secret"), last
+}
+
+func TestGetErrors(t *testing.T) {
+	c, _ := testServer(t, http.StatusUnauthorized, `{"error": "unauthorized"}`)
+	_, err := c.CurrentUser(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("CurrentUser() error = %v, want an APIError 401", err)
+	}
+
+	c, _ = testServer(t, http.StatusOK, `not json`)
+	if _, err := c.CurrentUser(context.Background()); err == nil {
+		t.Error("CurrentUser() with an invalid body: error = nil")
+	}
+}
+
+func TestStatsPartial(t *testing.T) {
+	c, req := testServer(t, http.StatusAccepted, `{"data": {"total_seconds": 60, "percent_calculated": 40}}`)
+	stats, err := c.Stats(context.Background(), "last_year")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stats.StillComputing() || stats.Data.PercentCalculated != 40 || stats.Data.TotalSeconds != 60 {
+		t.Errorf("Stats() on a 202 = %+v, want partial stats", stats.Data)
+	}
+	if req.URL.Path != "/api/compat/wakatime/v1/users/current/stats/last_year" {
+		t.Errorf("path = %s", req.URL.Path)
+	}
+	if user, _, ok := req.BasicAuth(); ok || req.Header.Get("Authorization") != "Basic c2VjcmV0" {
+		t.Errorf("Authorization = %q (basic auth user %q), want the base64 API key", req.Header.Get("Authorization"), user)
+	}
+
+	c, _ = testServer(t, http.StatusOK, `{"data": {"total_seconds": 60}}`)
+	if stats, err := c.Stats(context.Background(), "last_7_days"); err != nil || stats.StillComputing() {
+		t.Errorf("Stats() on a 200 without is_up_to_date = %v, %v, want complete stats", stats, err)
+	}
+}
+
+func TestQueries(t *testing.T) {
+	c, req := testServer(t, http.StatusOK, `{"data": []}`)
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		call      func() error
+		wantPath  string
+		wantQuery url.Values
+	}{
+		{"summaries", func() error { _, err := c.Summaries(ctx, "2026-10-01", "2026-10-07", nil); return err },
+			"/users/current/summaries", url.Values{"start": {"2026-10-01"}, "end": {"2026-10-07"}}},
+		{"summaries with options", func() error {
+			_, err := c.Summaries(ctx, "2026-10-01", "2026-10-07", &SummariesOptions{Project: "my app", Branches: []string{"main", "dev"}, Timezone: "Europe/Paris"})
+			return err
+		}, "/users/current/summaries", url.Values{"start": {"2026-10-01"}, "end": {"2026-10-07"}, "project": {"my app"}, "branches": {"main,dev"}, "timezone": {"Europe/Paris"}}},
+		{"public leaders", func() error { _, err := c.Leaders(ctx, nil); return err }, "/leaders", url.Values{}},
+		{"leaders with options", func() error {
+			_, err := c.Leaders(ctx, &LeadersOptions{Language: "C++", Page: 3})
+			return err
+		}, "/leaders", url.Values{"language": {"C++"}, "page": {"3"}}},
+		{"private leaders", func() error {
+			_, err := c.Leaders(ctx, &LeadersOptions{Board: "a/b"})
+			return err
+		}, "/users/current/leaderboards/a/b", url.Values{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if path := "/api/compat/wakatime/v1" + tt.wantPath; req.URL.Path != path {
+				t.Errorf("path = %s, want %s", req.URL.Path, path)
+			}
+			if got := req.URL.Query(); !(len(got) == 0 && len(tt.wantQuery) == 0) && got.Encode() != tt.wantQuery.Encode() {
+				t.Errorf("query = %s, want %s", got.Encode(), tt.wantQuery.Encode())
+			}
+		})
+	}
+	if got := req.URL.RawPath; got != "/api/compat/wakatime/v1/users/current/leaderboards/a%2Fb" {
+		t.Errorf("the board isn't escaped: %s", got)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := New(server.URL, "secret").CurrentUser(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CurrentUser() after cancel: error = %v, want context.Canceled", err)
+	}
+}
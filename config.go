@@ -5,21 +5,35 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
 	"github.com/andatoshiki/wakafetch/wakafetch/ui"
 )
 
-func parseConfig() (string, string, error) {
+// fileConfig is what we read from ~/.wakatime.cfg.
+// api_url/api_key come from any section (usually [settings]),
+// wakafetch's own display options live in the [wakafetch] section.
+type fileConfig struct {
+	apiURL  string
+	apiKey  string
+	options map[string]string
+}
+
+func parseConfig() (fileConfig, error) {
 	configPath := getConfigPath()
+	cfg := fileConfig{options: map[string]string{}}
 
 	file, err := os.Open(configPath)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to open config file: %w", err)
+		return cfg, fmt.Errorf("failed to open config file: %w", err)
 	}
 	defer file.Close()
 
 	var apiURL, apiKey string
+	section := ""
 	scanner := bufio.NewScanner(file)
 
 	for scanner.Scan() {
@@ -29,49 +43,117 @@ func parseConfig() (string, string, error) {
 			continue
 		}
 
-		if strings.HasPrefix(line, "api_url") {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				apiURL = strings.TrimSpace(parts[1])
-			}
-		} else if strings.HasPrefix(line, "api_key") {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				apiKey = strings.TrimSpace(parts[1])
-			}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
 		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
 
-		if apiURL != "" && apiKey != "" {
-			break
+		if section == "wakafetch" {
+			cfg.options[key] = value
+			continue
+		}
+
+		// first occurrence wins
+		if key == "api_url" && apiURL == "" {
+			apiURL = value
+		} else if key == "api_key" && apiKey == "" {
+			apiKey = value
 		}
 	}
 
 	if apiURL == "" {
-		return "", "", fmt.Errorf("api_url not found in config")
+		return cfg, fmt.Errorf("api_url not found in config")
 	}
 
 	if apiKey == "" {
-		return "", "", fmt.Errorf("api_key not found in config")
+		return cfg, fmt.Errorf("api_key not found in config")
+	}
+
+	cfg.apiURL = client.NormalizeURL(apiURL)
+	cfg.apiKey = apiKey
+	return cfg, nil
+}
+
+// applyDisplayOptions sets ui.Opts from the [wakafetch] config section, with flags taking precedence.
+func applyDisplayOptions(config Config, options map[string]string) {
+	cards := options["cards"]
+	if *config.cardsFlag != "" {
+		cards = *config.cardsFlag
+	}
+	if cards != "" {
+		specs, err := ui.ParseCards(cards)
+		if err != nil {
+			ui.Errorln("Invalid cards: %s", err.Error())
+		}
+		ui.Opts.Cards = specs
 	}
 
-	apiURL = strings.TrimSuffix(apiURL, "/")
-
-	// Normalize API URL for WakaTime vs Wakapi/self-hosted instances.
-	// - Official WakaTime: keep as-is (expects /v1/... paths).
-	// - Wakapi/self-hosted: ensure we point to the WakaTime-compatible prefix:
-	//   <base>/api/compat/wakatime
-	if !strings.Contains(apiURL, "wakatime.com") {
-		// Treat anything that's not the official WakaTime API as Wakapi-compatible.
-		if strings.HasSuffix(apiURL, "/api/compat/wakatime") {
-			// Already normalized
-		} else if strings.HasSuffix(apiURL, "/api") {
-			apiURL = apiURL + "/compat/wakatime"
-		} else {
-			apiURL = apiURL + "/api/compat/wakatime"
+	fields := options["fields"]
+	if *config.fieldsFlag != "" {
+		fields = *config.fieldsFlag
+	}
+	if fields != "" {
+		names, err := ui.ParseFields(fields)
+		if err != nil {
+			ui.Errorln("Invalid fields: %s", err.Error())
+		}
+		ui.Opts.Fields = names
+		// like the flags of summaryOnlyFlag: the streak needs the Summary API, which needs dates
+		if ui.Opts.NeedsDailyData() && config.command == "" && *config.rangeFlag == "all" && *config.daysFlag == 0 && !*config.heatmapFlag {
+			ui.Errorln("The streak field doesn't work with -r all: use a year or --days instead")
+		}
+	}
+
+	theme := options["theme"]
+	if *config.themeFlag != "" {
+		theme = *config.themeFlag
+	}
+	if theme != "" {
+		if err := ui.ValidateTheme(theme); err != nil {
+			ui.Errorln("Invalid theme: %s", err.Error())
 		}
+		ui.Opts.Theme = theme
 	}
 
-	return apiURL, apiKey, nil
+	ui.Opts.Fetch = *config.fetchFlag
+	logo := options["logo"]
+	if *config.logoFlag != "" {
+		logo = *config.logoFlag
+	}
+	if logo != "" {
+		parsed, err := ui.ParseLogo(logo)
+		if err != nil {
+			ui.Errorln("Invalid logo: %s", err.Error())
+		}
+		ui.Opts.Logo = parsed
+	}
+
+	if flagSet("limit", "l") {
+		ui.Opts.Limit = *config.limitFlag
+	} else if limit := options["limit"]; limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			ui.Errorln("Invalid limit in config: '%s', must be a positive integer", limit)
+		}
+		ui.Opts.Limit = n
+	}
+
+	if flagSet("min", "m") {
+		ui.Opts.MinSeconds = config.minFlag.Seconds()
+	} else if min := options["min"]; min != "" {
+		d, err := time.ParseDuration(min)
+		if err != nil {
+			ui.Errorln("Invalid min in config: '%s', must be a duration like 30s or 5m", min)
+		}
+		ui.Opts.MinSeconds = d.Seconds()
+	}
 }
 
 func getConfigPath() string {
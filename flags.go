@@ -4,22 +4,98 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/andatoshiki/wakafetch/wakafetch/ui"
 )
 
 type Config struct {
-	rangeFlag   *string
-	apiKeyFlag  *string
-	fullFlag    *bool
-	daysFlag    *int
-	dailyFlag   *bool
-	heatmapFlag *bool
-	jsonFlag    *bool
-	helpFlag    *bool
-	updateFlag  *bool
-	timeoutFlag *int
+	command      string // subcommand, "" for the default stats view
+	rangeFlag    *string
+	apiKeyFlag   *string
+	fullFlag     *bool
+	daysFlag     *int
+	dailyFlag    *bool
+	heatmapFlag  *bool
+	jsonFlag     *bool
+	helpFlag     *bool
+	updateFlag   *bool
+	timeoutFlag  *int
+	deadlineFlag *time.Duration
+	waitFlag     *bool
+	cardsFlag    *string
+	fieldsFlag   *string
+	fetchFlag    *bool
+	logoFlag     *string
+	limitFlag    *int
+	byFlag       *string
+	languageFlag *string
+	minFlag      *time.Duration
+	formatFlag   *string
+	templateFlag *string
+	csvFlag      *bool
+	tsvFlag      *bool
+	longFlag     *bool
+	markdownFlag *bool
+	htmlFlag     *string
+	svgFlag      *string
+	pngFlag      *string
+	scaleFlag    *int
+	themeFlag    *string
+	watchFlag    *watchValue
+
+	// serve
+	prometheusFlag *string
+	listenFlag     *string
+	rangesFlag     *string
+	intervalFlag   *time.Duration
+	topFlag        *int
+	cacheTTLFlag   *time.Duration // serve and status
+
+	// status
+	targetFlag *time.Duration
+	presetFlag *string
+
+	// heartbeats
+	dateFlag          *string
+	projectFilterFlag *string
+	branchFilterFlag  *string
+	editorFilterFlag  *string
+	machineFilterFlag *string
+	entityFilterFlag  *string
+	writesFlag        *bool
+
+	// leaders
+	boardFlag *string
+	pageFlag  *int
+
+	projectName string // wakafetch project <name>
+	// wakafetch org [<org> [<dashboard>]], names or IDs
+	orgName       string
+	dashboardName string
+
+	template *template.Template // parsed --format/--template
+}
+
+type commandInfo struct {
+	name        string
+	description string
+}
+
+// commands are the subcommands, given as the first argument (e.g. wakafetch serve --prometheus :9101).
+var commands = []commandInfo{
+	{"serve", "Run a server with Prometheus metrics, a JSON API and badges"},
+	{"status", "Print today's time as one line for tmux, polybar, waybar, starship, ..."},
+	{"project", "Show one project: its trend, languages, branches, editors and files"},
+	{"tui", "Explore your stats interactively: switch ranges, browse days and the heatmap"},
+	{"heartbeats", "List the raw heartbeats of a day, with the editors and machines that sent them"},
+	{"leaders", "Show a page of the public leaderboard, or of a private one with --board"},
+	{"org", "List your organizations' dashboards, or show a dashboard's members and combined time"},
+	{"me", "Show your account and total coding time since it was created, neofetch style"},
 }
 
 type flagInfo struct {
@@ -34,19 +110,73 @@ func parseFlags() Config {
 	config := Config{}
 	registeredFlags = nil
 
+	args := os.Args[1:]
+	if len(args) > 0 && isCommand(args[0]) {
+		config.command = args[0]
+		args = args[1:]
+	}
+	activeCommand = config.command
+
 	config.rangeFlag = config.stringFlag("range", "r", "today", "Range of data to fetch (today/yesterday/7d/30d/6m/1y/all/year) (default: today)")
 	config.daysFlag = config.intFlag("days", "d", 0, "Number of days to fetch data for (overrides --range)")
 	config.fullFlag = config.boolFlag("full", "f", false, "Display full statistics")
 	config.dailyFlag = config.boolFlag("daily", "D", false, "Display daily breakdown")
 	config.heatmapFlag = config.boolFlag("heatmap", "H", false, "Display heatmap of daily activity")
+	config.cardsFlag = config.stringFlag("cards", "c", "", "Cards to show in --full, with optional item limits (e.g. languages:10,projects,editors)")
+	config.fieldsFlag = config.stringFlag("fields", "F", "", "Stats fields to show, in order (e.g. total,avg,streak)")
+	config.fetchFlag = config.boolFlag("fetch", "", false, "Neofetch-like layout: a logo next to the stats, with color blocks")
+	config.logoFlag = config.stringFlag("logo", "", "", "Logo of --fetch: auto, language, editor, a built-in (go, python, vscode, ...) or a file (default: auto)")
+	config.byFlag = config.stringFlag("by", "", "", "Break the time down by: branch (grouped under project), dependency (grouped under language)")
+	config.languageFlag = config.stringFlag("language", "", "", "With --by dependency, heartbeats or leaders: only this language (e.g. Go)")
+	config.limitFlag = config.intFlag("limit", "l", 0, "Max items per card, the rest is collapsed into \"Other\" below them")
+	config.minFlag = config.durationFlag("min", "m", time.Minute, "Hide items below this time, collapsing them into \"Other\" (default: 1m)")
 	config.apiKeyFlag = config.stringFlag("api-key", "k", "", "Your WakaTime/Wakapi API key (overrides config)")
 	config.jsonFlag = config.boolFlag("json", "j", false, "Output data in JSON format")
+	config.formatFlag = config.stringFlag("format", "", "", "Output text from a Go template instead of cards (e.g. '{{.Total}} today, top: {{.TopLanguage}}')")
+	config.templateFlag = config.stringFlag("template", "", "", "Like --format, but read the template from a file")
+	config.csvFlag = config.boolFlag("csv", "", false, "Output CSV: one row per day (summary) or per item (stats)")
+	config.tsvFlag = config.boolFlag("tsv", "", false, "Like --csv, but tab separated")
+	config.longFlag = config.boolFlag("long", "", false, "With --csv/--tsv: tidy rows of date,dimension,name,seconds")
+	config.markdownFlag = config.boolFlag("markdown", "", false, "Output a Markdown report (with --daily/--heatmap tables)")
+	config.htmlFlag = config.stringFlag("html", "", "", "Write an interactive HTML report (uses the Summary API), - for stdout")
+	config.svgFlag = config.stringFlag("svg", "", "", "Write the cards (or the --heatmap calendar) to an SVG file, - for stdout")
+	config.pngFlag = config.stringFlag("png", "", "", "Write the cards (or the --heatmap calendar) to a PNG file, - for stdout")
+	config.scaleFlag = config.intFlag("scale", "", 2, "Pixel scale of --png output (default: 2)")
+	config.themeFlag = config.stringFlag("theme", "", "", "Theme for image and HTML output: dark, light, github-dark (default: dark)")
+	config.watchFlag = config.watchValueFlag("watch", "w", "Refetch and redraw every interval (default: 1m), e.g. --watch 30s")
 	config.helpFlag = config.boolFlag("help", "h", false, "Display help information")
 	config.updateFlag = config.boolFlag("update", "u", false, "Check for updates and show install command if newer version exists")
 	config.timeoutFlag = config.intFlag("timeout", "t", 10, "Request timeout in seconds")
+	config.waitFlag = config.boolFlag("wait", "", false, "Wait for WakaTime to finish computing the stats instead of showing partial ones")
+	config.deadlineFlag = config.durationFlag("deadline", "", 0, "Time limit for all the requests of a run, e.g. 30s (per refresh with --watch, tui and serve)")
+
+	switch config.command {
+	case "serve":
+		config.prometheusFlag = config.stringFlag("prometheus", "", "", "Address to serve Prometheus metrics on (e.g. :9101)")
+		config.rangesFlag = config.stringFlag("ranges", "", "today,7d,30d", "Ranges to export, comma separated (default: today,7d,30d)")
+		config.intervalFlag = config.durationFlag("interval", "", 5*time.Minute, "How often to refetch the stats (default: 5m)")
+		config.topFlag = config.intFlag("top", "", 10, "Max items per dimension and range, the rest is exported as \"Other\" (default: 10)")
+		config.listenFlag = config.stringFlag("listen", "", "", "Address to serve the JSON API and badges on (e.g. :8080)")
+		config.cacheTTLFlag = config.durationFlag("cache-ttl", "", 5*time.Minute, "How long API and badge responses are cached (default: 5m)")
+	case "status":
+		config.targetFlag = config.durationFlag("target", "", 0, "Daily goal to show progress against (e.g. 4h)")
+		config.presetFlag = config.stringFlag("preset", "", "plain", "Output for: plain, tmux, polybar, i3blocks, waybar, starship (default: plain)")
+		config.cacheTTLFlag = config.durationFlag("cache-ttl", "", time.Minute, "Reuse today's stats for this long between runs, 0 to always fetch (default: 1m)")
+	case "leaders":
+		config.boardFlag = config.stringFlag("board", "", "", "ID of a private leaderboard (WakaTime only), instead of the public one")
+		config.pageFlag = config.intFlag("page", "", 1, "Page of the leaderboard (default: 1)")
+	case "heartbeats":
+		config.dateFlag = config.stringFlag("date", "", "", "Day of the heartbeats, YYYY-MM-DD (default: today)")
+		config.projectFilterFlag = config.stringFlag("project", "", "", "Only heartbeats of this project")
+		config.branchFilterFlag = config.stringFlag("branch", "", "", "Only heartbeats on this branch")
+		config.editorFilterFlag = config.stringFlag("editor", "", "", "Only heartbeats sent by this editor (e.g. vscode)")
+		config.machineFilterFlag = config.stringFlag("machine", "", "", "Only heartbeats sent by this machine")
+		config.entityFilterFlag = config.stringFlag("entity", "", "", "Only heartbeats of files whose path contains this")
+		config.writesFlag = config.boolFlag("writes", "", false, "Only heartbeats sent when saving a file")
+	}
 
 	flag.Usage = showCustomHelp
-	flag.Parse()
+	positional := parseArgs(joinWatchInterval(args))
 
 	if !colorsShouldBeEnabled() {
 		ui.DisableColors()
@@ -60,32 +190,296 @@ func parseFlags() Config {
 		ui.Errorln("Invalid value for --days: must be a positive integer")
 	}
 
+	if *config.formatFlag != "" && *config.templateFlag != "" {
+		ui.Errorln("Use either --format or --template, not both")
+	}
+
+	config.template = loadTemplate(config)
+
+	if *config.csvFlag && *config.tsvFlag {
+		ui.Errorln("Use either --csv or --tsv, not both")
+	}
+
+	if *config.longFlag && !*config.csvFlag && !*config.tsvFlag {
+		ui.Errorln("--long only works with --csv or --tsv")
+	}
+
+	if *config.limitFlag < 0 {
+		ui.Errorln("Invalid value for --limit: must be a positive integer")
+	}
+
+	if *config.scaleFlag < 1 || *config.scaleFlag > 8 {
+		ui.Errorln("Invalid value for --scale: must be between 1 and 8")
+	}
+
+	if *config.svgFlag != "" && *config.pngFlag != "" {
+		ui.Errorln("Use either --svg or --png, not both")
+	}
+
+	// checked before fetching: --watch can't exit once it's on the alternate screen
+	if config.command == "" {
+		if !isValidRange(*config.rangeFlag) {
+			ui.Errorln("Invalid range: '%s', must be one of today, yesterday, 7d, 30d, 6m, 1y, all, or a year (e.g. 2024)", *config.rangeFlag)
+		}
+		if name := summaryOnlyFlag(config); name != "" && *config.rangeFlag == "all" && *config.daysFlag == 0 && !*config.heatmapFlag {
+			ui.Errorln("%s doesn't work with -r all: use a year or --days instead", name)
+		}
+	}
+
+	if config.watchFlag.interval > 0 {
+		if config.command != "" {
+			ui.Errorln("--watch only works with the default view, not with '%s'", config.command)
+		}
+		if config.watchFlag.interval < 10*time.Second {
+			ui.Errorln("Invalid value for --watch: the interval must be at least 10s")
+		}
+		if *config.jsonFlag || config.template != nil || csvComma(config) != 0 || *config.markdownFlag ||
+			*config.htmlFlag != "" || *config.svgFlag != "" || *config.pngFlag != "" {
+			ui.Errorln("--watch only works with the card views, not with file or text output")
+		}
+	}
+
+	if *config.fetchFlag && (config.command != "" || *config.dailyFlag || *config.heatmapFlag || *config.byFlag != "" ||
+		*config.jsonFlag || config.template != nil || csvComma(config) != 0 || *config.markdownFlag || *config.htmlFlag != "") {
+		ui.Errorln("--fetch only works with the default view, with --full, --watch, --svg and --png")
+	}
+
+	if *config.logoFlag != "" && !*config.fetchFlag {
+		ui.Errorln("--logo only works with --fetch")
+	}
+
+	if *config.waitFlag && (config.command != "" || config.watchFlag.interval > 0 || *config.byFlag != "") {
+		ui.Errorln("--wait only works with the default view, without --watch or --by")
+	}
+
+	if config.cacheTTLFlag != nil && *config.cacheTTLFlag < 0 {
+		ui.Errorln("Invalid value for --cache-ttl: must not be negative")
+	}
+
+	if *config.languageFlag != "" && *config.byFlag != "dependency" && config.command != "heartbeats" && config.command != "leaders" {
+		ui.Errorln("--language only works with --by dependency, heartbeats and leaders")
+	}
+
+	if *config.byFlag != "" {
+		if !slices.Contains(byDimensions, *config.byFlag) {
+			ui.Errorln("Invalid value for --by: '%s', must be one of: %s", *config.byFlag, strings.Join(byDimensions, ", "))
+		}
+		if config.command != "" {
+			ui.Errorln("--by only works with the default view, not with '%s'", config.command)
+		}
+		if *config.dailyFlag || *config.heatmapFlag || config.template != nil || csvComma(config) != 0 ||
+			*config.markdownFlag || *config.htmlFlag != "" || config.watchFlag.interval > 0 {
+			ui.Errorln("--by works with the cards, --json, --svg and --png")
+		}
+	}
+
+	switch config.command {
+	case "project":
+		if len(positional) != 1 {
+			ui.Errorln("Usage: wakafetch project <name> [options], e.g. wakafetch project wakafetch -r 30d")
+		}
+		config.projectName = positional[0]
+	case "heartbeats":
+		if *config.dateFlag == "" {
+			*config.dateFlag = time.Now().Format("2006-01-02")
+		}
+		if _, err := time.Parse("2006-01-02", *config.dateFlag); err != nil {
+			ui.Errorln("Invalid value for --date: '%s', must be YYYY-MM-DD (e.g. 2026-10-18)", *config.dateFlag)
+		}
+		if *config.dailyFlag || *config.heatmapFlag || *config.fullFlag || config.template != nil ||
+			*config.markdownFlag || *config.htmlFlag != "" || *config.longFlag {
+			ui.Errorln("heartbeats works with the table, --json, --csv, --tsv, --svg and --png")
+		}
+	case "org":
+		if len(positional) > 2 {
+			ui.Errorln("Usage: wakafetch org [<org> [<dashboard>]] [options], e.g. wakafetch org acme backend -r 14d")
+		}
+		if len(positional) > 0 {
+			config.orgName = positional[0]
+		}
+		if len(positional) > 1 {
+			config.dashboardName = positional[1]
+		}
+		if config.dashboardName == "" && (*config.dailyFlag || *config.heatmapFlag || *config.fullFlag || config.template != nil ||
+			csvComma(config) != 0 || *config.markdownFlag || *config.htmlFlag != "" || *config.longFlag) {
+			ui.Errorln("Listing dashboards works with the table, --json, --svg and --png; give a dashboard for the rest")
+		}
+	case "leaders":
+		if *config.pageFlag < 1 {
+			ui.Errorln("Invalid value for --page: must be a positive integer")
+		}
+		if *config.dailyFlag || *config.heatmapFlag || *config.fullFlag || config.template != nil || csvComma(config) != 0 ||
+			*config.markdownFlag || *config.htmlFlag != "" || *config.longFlag {
+			ui.Errorln("leaders works with the table, --json, --svg and --png")
+		}
+	case "me":
+		if *config.dailyFlag || *config.heatmapFlag || *config.fullFlag || config.template != nil || csvComma(config) != 0 ||
+			*config.markdownFlag || *config.htmlFlag != "" || *config.longFlag {
+			ui.Errorln("me works with the card, --json, --svg and --png")
+		}
+	case "serve":
+		if *config.prometheusFlag == "" && *config.listenFlag == "" {
+			ui.Errorln("Nothing to serve: use --prometheus ADDR and/or --listen ADDR (e.g. --listen :8080)")
+		}
+		if *config.intervalFlag < 30*time.Second {
+			ui.Errorln("Invalid value for --interval: must be at least 30s")
+		}
+		if *config.topFlag < 1 {
+			ui.Errorln("Invalid value for --top: must be a positive integer")
+		}
+	case "status":
+		if *config.targetFlag < 0 {
+			ui.Errorln("Invalid value for --target: must not be negative")
+		}
+		if !isStatusPreset(*config.presetFlag) {
+			ui.Errorln("Invalid value for --preset: '%s', must be one of: %s", *config.presetFlag, strings.Join(statusPresets, ", "))
+		}
+	}
+
 	return config
 }
 
 var registeredFlags []flagInfo
 
+// activeCommand is the subcommand being run, for the help text.
+var activeCommand string
+
+func isCommand(name string) bool {
+	for _, c := range commands {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Config) stringFlag(long, short, def, desc string) *string {
 	registeredFlags = append(registeredFlags, flagInfo{long, short, def, desc, "string"})
 	val := flag.String(long, def, "")
-	flag.StringVar(val, short, def, "")
+	if short != "" {
+		flag.StringVar(val, short, def, "")
+	}
 	return val
 }
 
 func (c *Config) boolFlag(long, short string, def bool, desc string) *bool {
 	registeredFlags = append(registeredFlags, flagInfo{long, short, def, desc, ""})
 	val := flag.Bool(long, def, "")
-	flag.BoolVar(val, short, def, "")
+	if short != "" {
+		flag.BoolVar(val, short, def, "")
+	}
 	return val
 }
 
 func (c *Config) intFlag(long, short string, def int, desc string) *int {
 	registeredFlags = append(registeredFlags, flagInfo{long, short, def, desc, "int"})
 	val := flag.Int(long, def, "")
-	flag.IntVar(val, short, def, "")
+	if short != "" {
+		flag.IntVar(val, short, def, "")
+	}
+	return val
+}
+
+func (c *Config) durationFlag(long, short string, def time.Duration, desc string) *time.Duration {
+	registeredFlags = append(registeredFlags, flagInfo{long, short, def, desc, "duration"})
+	val := flag.Duration(long, def, "")
+	if short != "" {
+		flag.DurationVar(val, short, def, "")
+	}
+	return val
+}
+
+// watchValue is --watch's optional interval: "--watch" alone, "--watch 30s", "--watch=30s" or "--watch 30" (seconds).
+type watchValue struct {
+	interval time.Duration // 0 when not watching
+}
+
+const defaultWatchInterval = time.Minute
+
+func (v *watchValue) String() string {
+	if v == nil || v.interval == 0 {
+		return ""
+	}
+	return v.interval.String()
+}
+
+func (v *watchValue) Set(s string) error {
+	switch s {
+	case "true":
+		v.interval = defaultWatchInterval
+		return nil
+	case "false":
+		v.interval = 0
+		return nil
+	}
+	interval, err := parseWatchInterval(s)
+	if err != nil {
+		return err
+	}
+	v.interval = interval
+	return nil
+}
+
+// IsBoolFlag lets --watch be given without a value.
+func (v *watchValue) IsBoolFlag() bool { return true }
+
+func parseWatchInterval(s string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(s); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func (c *Config) watchValueFlag(long, short, desc string) *watchValue {
+	registeredFlags = append(registeredFlags, flagInfo{long, short, "", desc, "[interval]"})
+	val := &watchValue{}
+	flag.Var(val, long, "")
+	if short != "" {
+		flag.Var(val, short, "")
+	}
 	return val
 }
 
+// joinWatchInterval rewrites "--watch 30s" as "--watch=30s": the flag package
+// would otherwise take the interval of a value-less flag for a positional argument.
+func joinWatchInterval(args []string) []string {
+	joined := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if (arg == "--watch" || arg == "-watch" || arg == "-w") && i+1 < len(args) {
+			if _, err := parseWatchInterval(args[i+1]); err == nil {
+				arg += "=" + args[i+1]
+				i++
+			}
+		}
+		joined = append(joined, arg)
+	}
+	return joined
+}
+
+// parseArgs parses the flags in args, also the ones after a positional argument
+// (wakafetch project NAME -r 30d), and returns the positional arguments.
+func parseArgs(args []string) []string {
+	var positional []string
+	flag.CommandLine.Parse(args)
+	for flag.NArg() > 0 {
+		positional = append(positional, flag.Arg(0))
+		flag.CommandLine.Parse(flag.Args()[1:])
+	}
+	return positional
+}
+
+// flagSet reports whether a flag was given on the command line, by its long or short name.
+func flagSet(long, short string) bool {
+	found := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == long || f.Name == short {
+			found = true
+		}
+	})
+	return found
+}
+
 func showCustomHelp() {
 	// ASCII title and version / credits
 	fmt.Println(ui.Clr.Green + "▗▖ ▗▖ ▗▄▖ ▗▖ ▗▖ ▗▄▖ ▗▄▄▄▖▗▄▄▄▖▗▄▄▄▖▗▄▄▖▗▖ ▗▖" + ui.Clr.Reset)
@@ -98,12 +492,20 @@ func showCustomHelp() {
 	fmt.Println(ui.Clr.Blue + " A colorful WakaTime/Wakapi stats fetcher for your terminal (without needing to open/refresh the web dashboard every time)" + ui.Clr.Reset)
 	fmt.Println(" Original author: " + ui.Clr.Green + "sahaj-b" + ui.Clr.Reset + "  |  Current maintainer: " + ui.Clr.Green + "andatoshiki" + ui.Clr.Reset + " (" + ui.Clr.Blue + "https://www.toshiki.dev" + ui.Clr.Reset + ")")
 	fmt.Println()
-	fmt.Println(ui.Clr.Bold + "Usage:" + ui.Clr.Reset + " wakafetch [options]")
+	if activeCommand != "" {
+		fmt.Println(ui.Clr.Bold + "Usage:" + ui.Clr.Reset + " wakafetch " + activeCommand + " [options]")
+	} else {
+		fmt.Println(ui.Clr.Bold + "Usage:" + ui.Clr.Reset + " wakafetch [command] [options]")
+		fmt.Println(ui.Clr.Bold + "Commands:" + ui.Clr.Reset)
+		for _, c := range commands {
+			fmt.Printf("  %s%-10s%s%s\n", ui.Clr.Green, c.name, ui.Clr.Reset, c.description)
+		}
+	}
 	fmt.Println(ui.Clr.Bold + "Options:" + ui.Clr.Reset)
 
 	maxWidth := 0
 	for _, f := range registeredFlags {
-		width := len("-" + f.shortName + ", --" + f.longName + " " + f.flagType)
+		width := len("-x, --" + f.longName + " " + f.flagType)
 		if width > maxWidth {
 			maxWidth = width
 		}
@@ -111,6 +513,9 @@ func showCustomHelp() {
 
 	for _, f := range registeredFlags {
 		flag := fmt.Sprintf("-%s, --%s", f.shortName, f.longName)
+		if f.shortName == "" {
+			flag = "    --" + f.longName
+		}
 		flagLen := len(flag)
 		if f.flagType != "" {
 			flagLen = len(flag + " " + f.flagType)
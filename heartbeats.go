@@ -0,0 +1,123 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// heartbeatsOutput is the --json output of the heartbeats command.
+type heartbeatsOutput struct {
+	Date       string              `json:"date"`
+	Summary    ui.HeartbeatSummary `json:"summary"`
+	Heartbeats []ui.HeartbeatRow   `json:"heartbeats"`
+}
+
+// runHeartbeats shows the raw heartbeats of --date, filtered by --project, --language, ...,
+// with a summary of the editors and machines that sent them and the gaps between them.
+func runHeartbeats(ctx context.Context, config Config, apiKey, apiURL string) {
+	date := *config.dateFlag
+	data, err := fetchHeartbeats(ctx, apiKey, apiURL, date, *config.timeoutFlag)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	editors, machines := heartbeatSourceNames(ctx, config, apiKey, apiURL)
+
+	rows := []ui.HeartbeatRow{}
+	for _, hb := range data.Data {
+		row := ui.HeartbeatRow{
+			Heartbeat: hb,
+			Editor:    cmp.Or(editors[hb.UserAgentID], editorFromUserAgent(hb.UserAgentID)),
+			Machine:   cmp.Or(machines[hb.MachineNameID], hb.MachineNameID),
+		}
+		if matchesHeartbeatFilters(config, row) {
+			rows = append(rows, row)
+		}
+	}
+	ui.SortHeartbeats(rows)
+	summary := ui.SummarizeHeartbeats(rows)
+
+	display := func() { ui.DisplayHeartbeats(rows, summary, date) }
+	switch {
+	case *config.jsonFlag:
+		outputJSON(heartbeatsOutput{Date: date, Summary: summary, Heartbeats: rows})
+	case csvComma(config) != 0:
+		if err := ui.WriteHeartbeatsCSV(os.Stdout, rows, csvComma(config)); err != nil {
+			ui.Errorln("Failed to write CSV: %s", err.Error())
+		}
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
+
+// heartbeatSourceNames maps the user agent and machine IDs of heartbeats to editor and
+// machine names. Only WakaTime has IDs to look up: Wakapi's heartbeats have the names.
+// The heartbeats are still shown when the lookup fails, with their IDs.
+func heartbeatSourceNames(ctx context.Context, config Config, apiKey, apiURL string) (editors, machines map[string]string) {
+	editors, machines = map[string]string{}, map[string]string{}
+	if !newClient(apiKey, apiURL, *config.timeoutFlag).Capabilities().UserAgents {
+		return editors, machines
+	}
+	if agents, err := fetchUserAgents(ctx, apiKey, apiURL, *config.timeoutFlag); err != nil {
+		ui.Warnln("%s (showing user agent IDs)", err.Error())
+	} else {
+		for _, agent := range agents.Data {
+			editors[agent.ID] = cmp.Or(agent.Editor, editorFromUserAgent(agent.Value))
+		}
+	}
+	if names, err := fetchMachineNames(ctx, apiKey, apiURL, *config.timeoutFlag); err != nil {
+		ui.Warnln("%s (showing machine IDs)", err.Error())
+	} else {
+		for _, m := range names.Data {
+			machines[m.ID] = cmp.Or(m.Value, m.Name)
+		}
+	}
+	return editors, machines
+}
+
+// editorFromUserAgent finds the editor in a plugin's user agent: "vscode" in
+// "wakatime/v1.73.0 (linux-6.1.0) go1.20.3 vscode/1.78.2 vscode-wakatime/24.0.10".
+// Anything else (an ID, another format) is returned as is.
+func editorFromUserAgent(ua string) string {
+	fields := strings.Fields(ua)
+	for i := len(fields) - 1; i > 0; i-- {
+		plugin, _, ok := strings.Cut(fields[i], "/")
+		if !ok || !strings.HasSuffix(plugin, "-wakatime") {
+			continue
+		}
+		// the editor comes right before its plugin, unless the plugin follows wakatime-cli's own
+		if editor, _, ok := strings.Cut(fields[i-1], "/"); ok && editor != "wakatime" {
+			return editor
+		}
+		return strings.TrimSuffix(plugin, "-wakatime")
+	}
+	return ua
+}
+
+// matchesHeartbeatFilters reports whether a heartbeat passes the filter flags: --project,
+// --branch, --language, --editor and --machine match whole names (ignoring case),
+// --entity a part of the path, and --writes keeps only writes.
+func matchesHeartbeatFilters(config Config, row ui.HeartbeatRow) bool {
+	for _, f := range []struct{ want, got string }{
+		{*config.projectFilterFlag, row.Project},
+		{*config.branchFilterFlag, row.Branch},
+		{*config.languageFlag, row.Language},
+		{*config.editorFilterFlag, row.Editor},
+		{*config.machineFilterFlag, row.Machine},
+	} {
+		if f.want != "" && !strings.EqualFold(f.want, f.got) {
+			return false
+		}
+	}
+	if *config.entityFilterFlag != "" && !strings.Contains(strings.ToLower(row.Entity), strings.ToLower(*config.entityFilterFlag)) {
+		return false
+	}
+	return !*config.writesFlag || row.IsWrite
+}
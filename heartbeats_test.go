@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestEditorFromUserAgent(t *testing.T) {
+	tests := map[string]string{
+		"wakatime/v1.73.0 (linux-6.1.0) go1.20.3 vscode/1.78.2 vscode-wakatime/24.0.10":    "vscode",
+		"wakatime/v1.90.0 (darwin-23.1.0-arm64) go1.21.4 neovim/0.9.4 vim-wakatime/11.1.1": "neovim",
+		"wakatime/v1.90.0 (linux) go1.21.4 vim-wakatime/11.1.1":                            "vim",
+		"vim-wakatime/11.1.1":                      "vim-wakatime/11.1.1",
+		"wakatime/v1.90.0 sublime-wakatime/10.0.1": "sublime",
+		"Sublime Text":                             "Sublime Text",
+		"1f0c1a2e-user-agent-id":                   "1f0c1a2e-user-agent-id",
+		"":                                         "",
+	}
+	for ua, want := range tests {
+		if got := editorFromUserAgent(ua); got != want {
+			t.Errorf("editorFromUserAgent(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
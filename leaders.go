@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// runLeaders shows a page of the public leaderboard, or of the private one of --board,
+// ranked for --language if given.
+func runLeaders(ctx context.Context, config Config, apiKey, apiURL string) {
+	board := "Public leaderboard"
+	if *config.boardFlag != "" {
+		if !newClient(apiKey, apiURL, *config.timeoutFlag).Capabilities().PrivateLeaderboards {
+			ui.Errorln("Private leaderboards (--board) are only available on WakaTime")
+		}
+		board = "Leaderboard " + *config.boardFlag
+	}
+	opts := &client.LeadersOptions{Board: *config.boardFlag, Language: *config.languageFlag, Page: *config.pageFlag}
+	data, err := fetchLeaders(ctx, apiKey, apiURL, opts, *config.timeoutFlag)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	if data.TotalPages > 0 && *config.pageFlag > data.TotalPages {
+		ui.Warnln("The leaderboard has %d pages, there's no page %d", data.TotalPages, *config.pageFlag)
+	}
+
+	if *config.jsonFlag {
+		outputJSON(data)
+		return
+	}
+	you := leadersYou(ctx, config, apiKey, apiURL, data)
+	display := func() { ui.DisplayLeaders(data, board, you) }
+	switch {
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
+
+// leadersYou is the user ID of the API key when the leaderboard doesn't say (no current_user),
+// to highlight your row anyway; "" when current_user is there or /users/current fails.
+func leadersYou(ctx context.Context, config Config, apiKey, apiURL string, data *types.LeadersResponse) string {
+	if len(data.Data) == 0 || (data.CurrentUser != nil && data.CurrentUser.User.ID != "") {
+		return ""
+	}
+	user, err := fetchCurrentUser(ctx, apiKey, apiURL, *config.timeoutFlag)
+	if err != nil {
+		ui.Warnln("Couldn't tell which row is yours: %s", err.Error())
+		return ""
+	}
+	return user.Data.ID
+}
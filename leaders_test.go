@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestLeadersYou(t *testing.T) {
+	status, requests := http.StatusOK, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/compat/wakatime/v1/users/current" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"data": {"id": "id-me", "username": "me"}}`))
+	}))
+	defer server.Close()
+
+	timeout := 5
+	config := Config{timeoutFlag: &timeout}
+	leaders := func(body string) *types.LeadersResponse {
+		var data types.LeadersResponse
+		if err := json.Unmarshal([]byte(body), &data); err != nil {
+			t.Fatal(err)
+		}
+		return &data
+	}
+	tests := []struct {
+		name         string
+		data         *types.LeadersResponse
+		status       int
+		want         string
+		wantRequests int
+	}{
+		{"current_user", leaders(`{"data": [{"rank": 1}], "current_user": {"rank": 1, "user": {"id": "id-me"}}}`), 200, "", 0},
+		{"no current_user", leaders(`{"data": [{"rank": 1}]}`), 200, "id-me", 1},
+		{"current_user without a user", leaders(`{"data": [{"rank": 1}], "current_user": {"rank": 0}}`), 200, "id-me", 1},
+		{"/users/current fails", leaders(`{"data": [{"rank": 1}]}`), 401, "", 1},
+		{"empty board", leaders(`{"data": []}`), 200, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, requests = tt.status, 0
+			if got := leadersYou(context.Background(), config, "key", server.URL, tt.data); got != tt.want {
+				t.Errorf("leadersYou() = %q, want %q", got, tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("%d requests to /users/current, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
 	"github.com/andatoshiki/wakafetch/wakafetch/types"
 	"github.com/andatoshiki/wakafetch/wakafetch/ui"
 )
@@ -28,34 +32,89 @@ import (
 
 func main() {
 	config := parseFlags()
+	// Ctrl-C and SIGTERM cancel the requests in flight instead of killing the process,
+	// so every command stops cleanly (and restores the terminal)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// once the first signal is in, a second one kills the process as usual
+	context.AfterFunc(ctx, stop)
+
 	if *config.updateFlag {
-		runUpdateCheck(*config.timeoutFlag)
+		ctx, cancel := withDeadline(ctx, config)
+		defer cancel()
+		runUpdateCheck(ctx, *config.timeoutFlag)
 		return
 	}
 	apiURL, apiKey := loadAPIConfig(config)
 
+	// these run until they're stopped: --deadline applies to each of their fetches
+	switch config.command {
+	case "serve":
+		runServe(ctx, config, apiKey, apiURL)
+		return
+	case "tui":
+		runTUI(ctx, config, apiKey, apiURL)
+		return
+	}
+	if config.watchFlag.interval > 0 {
+		runWatch(ctx, config, apiKey, apiURL)
+		return
+	}
+
+	ctx, cancel := withDeadline(ctx, config)
+	defer cancel()
+
+	switch config.command {
+	case "status":
+		runStatus(ctx, config, apiKey, apiURL)
+		return
+	case "project":
+		runProject(ctx, config, apiKey, apiURL)
+		return
+	case "heartbeats":
+		runHeartbeats(ctx, config, apiKey, apiURL)
+		return
+	case "leaders":
+		runLeaders(ctx, config, apiKey, apiURL)
+		return
+	case "org":
+		runOrg(ctx, config, apiKey, apiURL)
+		return
+	case "me":
+		runMe(ctx, config, apiKey, apiURL)
+		return
+	}
+
+	if *config.byFlag != "" {
+		runBy(ctx, config, apiKey, apiURL)
+		return
+	}
+
 	if shouldUseSummaryAPI(config, apiURL) {
-		handleSummaryFlow(config, apiKey, apiURL)
+		handleSummaryFlow(ctx, config, apiKey, apiURL)
 	} else {
-		handleStatsFlow(config, apiKey, apiURL)
+		handleStatsFlow(ctx, config, apiKey, apiURL)
 	}
 }
 
 func loadAPIConfig(config Config) (string, string) {
-	apiURL, apiKey, err := parseConfig()
+	cfg, err := parseConfig()
 	if err != nil {
 		ui.Errorln(err.Error())
 	}
+	applyDisplayOptions(config, cfg.options)
 
+	apiKey := cfg.apiKey
 	if *config.apiKeyFlag != "" {
 		apiKey = *config.apiKeyFlag
 	}
 
-	return apiURL, apiKey
+	return cfg.apiURL, apiKey
 }
 
 func shouldUseSummaryAPI(config Config, apiURL string) bool {
-	// Use summary API if: days flag is set, daily/heatmap flags are set, or range is a year.
+	// Use summary API if: days flag is set, daily/heatmap flags are set, range is a year,
+	// a selected stats field needs per-day data (e.g. streak), or --long CSV rows or an --html report are requested.
 	// For today/yesterday: only WakaTime requires summary (its stats API does not support those);
 	// Wakapi stats API supports today, yesterday, last_7_days, etc., so we use stats there.
 	_, isYear := parseYear(*config.rangeFlag)
@@ -65,60 +124,86 @@ func shouldUseSummaryAPI(config Config, apiURL string) bool {
 			return true
 		}
 	}
-	return *config.daysFlag != 0 || *config.dailyFlag || *config.heatmapFlag || isYear
+	return *config.daysFlag != 0 || *config.dailyFlag || *config.heatmapFlag || isYear || ui.Opts.NeedsDailyData() || *config.longFlag || *config.htmlFlag != ""
+}
+
+// summaryOnlyFlag names the flag that makes the default view use the Summary API whatever
+// the range, "" when there's none. The Summary API needs dates, so those flags can't do -r all.
+func summaryOnlyFlag(config Config) string {
+	switch {
+	case *config.dailyFlag:
+		return "--daily"
+	case *config.longFlag:
+		return "--long"
+	case *config.htmlFlag != "":
+		return "--html"
+	}
+	return ""
 }
 
 // isWakaTimeAPI returns true when apiURL is the official WakaTime API (not Wakapi/self-hosted).
 func isWakaTimeAPI(apiURL string) bool {
-	return strings.Contains(apiURL, "wakatime.com")
+	return client.DetectBackend(apiURL) == client.WakaTime
+}
+
+func handleStatsFlow(ctx context.Context, config Config, apiKey, apiURL string) {
+	data, apiRangeStr, err := fetchStatsFlow(ctx, config, apiKey, apiURL)
+	if err == nil && *config.waitFlag && data.StillComputing() {
+		data, err = waitForStats(ctx, config, apiKey, apiURL, apiRangeStr, data)
+	}
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	outputStats(config, data, apiRangeStr)
 }
 
-func handleStatsFlow(config Config, apiKey, apiURL string) {
-       rangeStr := *config.rangeFlag
-       // If --full is set and range is default (today), override to 7d
-       if *config.fullFlag && (rangeStr == "" || rangeStr == "today") {
-	       rangeStr = "7d"
-       }
-       apiRangeStr := getRangeStr(rangeStr)
-
-       data, err := fetchStats(apiKey, apiURL, apiRangeStr, *config.timeoutFlag)
-       if err != nil {
-	       ui.Errorln(err.Error())
-       }
-
-       if *config.jsonFlag {
-	       outputJSON(data)
-	       return
-       }
-
-       ui.DisplayStats(data, *config.fullFlag, apiRangeStr)
+// fetchStatsFlow fetches the /stats data of the default view, returning it with its API range.
+func fetchStatsFlow(ctx context.Context, config Config, apiKey, apiURL string) (*types.StatsResponse, string, error) {
+	rangeStr := *config.rangeFlag
+	// If --full is set and range is default (today), override to 7d
+	if *config.fullFlag && (rangeStr == "" || rangeStr == "today") {
+		rangeStr = "7d"
+	}
+	apiRangeStr := getRangeStr(rangeStr)
+
+	data, err := fetchStats(ctx, apiKey, apiURL, apiRangeStr, *config.timeoutFlag)
+	return data, apiRangeStr, err
+}
+
+func handleSummaryFlow(ctx context.Context, config Config, apiKey, apiURL string) {
+	data, heading, err := fetchSummaryFlow(ctx, config, apiKey, apiURL)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	outputSummary(config, data, heading)
 }
 
-func handleSummaryFlow(config Config, apiKey, apiURL string) {
+// fetchSummaryFlow fetches the /summaries data of the default view, returning it with its heading.
+// Invalid flags exit, only fetch errors are returned.
+func fetchSummaryFlow(ctx context.Context, config Config, apiKey, apiURL string) (*types.SummaryResponse, string, error) {
 	var data *types.SummaryResponse
 	var err error
 	var heading string
 
-	       // If --full is set and range is default (today), override to 7d
-	       rangeFlag := *config.rangeFlag
-	       if *config.fullFlag && (rangeFlag == "" || rangeFlag == "today") {
-		       rangeFlag = "7d"
-	       }
-	       // Check if range flag is a year number
-	       year, isYear := parseYear(rangeFlag)
-	       if isYear {
-		       // Fetch data for the entire year
-		       startDate := fmt.Sprintf("%d-01-01", year)
-		       endDate := fmt.Sprintf("%d-12-31", year)
-		       data, err = fetchSummaryWithDates(apiKey, apiURL, startDate, endDate, *config.timeoutFlag)
-		       if err != nil {
-			       ui.Errorln(err.Error())
-			       return
-		       }
-		       heading = fmt.Sprintf("Year %d", year)
-	       } else {
-		       // Non-year ranges
-		       // Use rangeFlag instead of *config.rangeFlag below
+	// If --full is set and range is default (today), override to 7d
+	rangeFlag := *config.rangeFlag
+	if *config.fullFlag && (rangeFlag == "" || rangeFlag == "today") {
+		rangeFlag = "7d"
+	}
+	// Check if range flag is a year number
+	year, isYear := parseYear(rangeFlag)
+	if isYear {
+		// Fetch data for the entire year
+		startDate := fmt.Sprintf("%d-01-01", year)
+		endDate := fmt.Sprintf("%d-12-31", year)
+		data, err = fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, nil, *config.timeoutFlag)
+		if err != nil {
+			return nil, "", err
+		}
+		heading = fmt.Sprintf("Year %d", year)
+	} else {
+		// Non-year ranges
+		// Use rangeFlag instead of *config.rangeFlag below
 		if *config.heatmapFlag {
 			// Heatmap default: when range is today/yesterday (CLI default), use backend-aware window. Otherwise respect --range.
 			heatmapRange := *config.rangeFlag
@@ -132,13 +217,12 @@ func handleSummaryFlow(config Config, apiKey, apiURL string) {
 			startDate, endDate, head, valid := getSummaryRange(heatmapRange)
 			if !valid {
 				ui.Errorln("Invalid range for heatmap: use today, yesterday, 7d, 30d, 6m, 1y, or a year (e.g. 2024)")
-				return
+				return nil, "", nil
 			}
 			heading = head
-			data, err = fetchSummaryWithDates(apiKey, apiURL, startDate, endDate, *config.timeoutFlag)
+			data, err = fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, nil, *config.timeoutFlag)
 			if err != nil {
-				ui.Errorln(err.Error())
-				return
+				return nil, "", err
 			}
 		} else {
 			// Use existing logic for preset ranges
@@ -146,10 +230,10 @@ func handleSummaryFlow(config Config, apiKey, apiURL string) {
 			days := *config.daysFlag
 
 			// WakaTime stats API does not support "today" or "yesterday"; use summary with exact dates.
-			if rangeStr == "today" || rangeStr == "yesterday" {
+			if days == 0 && (rangeStr == "today" || rangeStr == "yesterday") {
 				startDate, endDate, head, _ := getSummaryRange(*config.rangeFlag)
 				heading = head
-				data, err = fetchSummaryWithDates(apiKey, apiURL, startDate, endDate, *config.timeoutFlag)
+				data, err = fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, nil, *config.timeoutFlag)
 			} else if days == 0 {
 				validRange := true
 				days, validRange = map[string]int{
@@ -161,16 +245,15 @@ func handleSummaryFlow(config Config, apiKey, apiURL string) {
 
 				if !validRange {
 					ui.Errorln("This range isn't supported with `--daily` or `--heatmap` flags. Use `--days` instead")
-					return
+					return nil, "", nil
 				}
 
-				data, err = fetchSummary(apiKey, apiURL, days, *config.timeoutFlag)
+				data, err = fetchSummary(ctx, apiKey, apiURL, days, *config.timeoutFlag)
 			} else {
-				data, err = fetchSummary(apiKey, apiURL, days, *config.timeoutFlag)
+				data, err = fetchSummary(ctx, apiKey, apiURL, days, *config.timeoutFlag)
 			}
 			if err != nil {
-				ui.Errorln(err.Error())
-				return
+				return nil, "", err
 			}
 
 			if *config.daysFlag != 0 {
@@ -194,22 +277,7 @@ func handleSummaryFlow(config Config, apiKey, apiURL string) {
 		}
 	}
 
-	if *config.jsonFlag {
-		outputJSON(data)
-		return
-	}
-
-	if *config.dailyFlag {
-		ui.DisplayBreakdown(data.Data, heading)
-		return
-	}
-
-	if *config.heatmapFlag {
-		ui.DisplayHeatmap(data.Data, heading)
-		return
-	}
-
-	ui.DisplaySummary(data, *config.fullFlag, heading)
+	return data, heading, nil
 }
 
 func parseYear(rangeFlag string) (int, bool) {
@@ -261,6 +329,32 @@ func getSummaryRange(rangeFlag string) (startDate, endDate, heading string, vali
 	}
 }
 
+// summaryDates returns the dates and heading of --days or --range for the Summary API,
+// defaultRange standing in when --range isn't given.
+func summaryDates(config Config, defaultRange string) (startDate, endDate, heading string) {
+	if days := *config.daysFlag; days > 0 {
+		today := time.Now()
+		heading = fmt.Sprintf("Last %d days", days)
+		if days == 1 {
+			heading = "Today"
+		}
+		return today.AddDate(0, 0, -days+1).Format("2006-01-02"), today.Format("2006-01-02"), heading
+	}
+
+	rangeFlag := *config.rangeFlag
+	if !flagSet("range", "r") {
+		rangeFlag = defaultRange
+	}
+	if year, isYear := parseYear(rangeFlag); isYear {
+		return fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year), fmt.Sprintf("Year %d", year)
+	}
+	startDate, endDate, heading, valid := getSummaryRange(rangeFlag)
+	if !valid {
+		ui.Errorln("Invalid range: use today, yesterday, 7d, 30d, 6m, 1y, or a year (e.g. 2024)")
+	}
+	return startDate, endDate, heading
+}
+
 // getRangeStr maps user --range flag to the API range identifier for stats/summary.
 // WakaTime stats: last_7_days, last_30_days, last_6_months, last_year, all_time (no today/yesterday).
 // Wakapi stats: today, yesterday, last_7_days, 7_days, last_30_days, 30_days, last_6_months, 6_months,
@@ -317,12 +411,12 @@ func outputJSON(data any) {
 const updateCheckURL = "https://api.github.com/repos/andatoshiki/wakafetch/releases/latest"
 const installScriptURL = "https://raw.githubusercontent.com/andatoshiki/wakafetch/master/scripts/install.sh"
 
-func runUpdateCheck(timeoutSeconds int) {
+func runUpdateCheck(ctx context.Context, timeoutSeconds int) {
 	if timeoutSeconds <= 0 {
 		timeoutSeconds = 10
 	}
 	client := &http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second}
-	req, err := http.NewRequest("GET", updateCheckURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", updateCheckURL, nil)
 	if err != nil {
 		ui.Errorln("Update check failed: %s", err.Error())
 		return
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// meOutput is the --json output of the me command: both responses as received.
+type meOutput struct {
+	User              *types.UserResponse              `json:"user"`
+	AllTimeSinceToday *types.AllTimeSinceTodayResponse `json:"all_time_since_today"`
+}
+
+// runMe shows the account of the API key with its total time since it was created.
+// The card is still shown without the total when it can't be fetched.
+func runMe(ctx context.Context, config Config, apiKey, apiURL string) {
+	user, err := fetchCurrentUser(ctx, apiKey, apiURL, *config.timeoutFlag)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	allTime, err := fetchAllTimeSinceToday(ctx, apiKey, apiURL, *config.timeoutFlag)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		ui.Errorln(err.Error())
+	case err != nil:
+		ui.Warnln("%s (showing the account only)", err.Error())
+	case !allTime.Data.IsUpToDate && allTime.Data.PercentCalculated < 100:
+		ui.Warnln("Partial total: WakaTime has calculated %d%% of it so far", allTime.Data.PercentCalculated)
+	}
+
+	display := func() { ui.DisplayMe(user.Data, allTime, apiHost(apiURL)) }
+	switch {
+	case *config.jsonFlag:
+		outputJSON(meOutput{User: user, AllTimeSinceToday: allTime})
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
+
+// apiHost is the host of an API URL, for the username@host heading: "wakatime.com".
+func apiHost(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return apiURL
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// dashboardOutput is the --json output of org with a dashboard.
+type dashboardOutput struct {
+	Org       types.Org              `json:"org"`
+	Dashboard types.Dashboard        `json:"dashboard"`
+	Range     string                 `json:"range"`
+	Start     string                 `json:"start"`
+	End       string                 `json:"end"`
+	Members   []ui.MemberTotal       `json:"members"`
+	Summary   *types.SummaryResponse `json:"summary"` // of all the members
+}
+
+// runOrg lists the dashboards of the user's organizations (of one, with <org>). With a
+// dashboard too, it shows the time of each member over --range (default: 7d) or --days,
+// then the members' summaries added up, in any of the summary outputs.
+func runOrg(ctx context.Context, config Config, apiKey, apiURL string) {
+	if !newClient(apiKey, apiURL, *config.timeoutFlag).Capabilities().Orgs {
+		ui.Errorln("Organizations are only available on WakaTime")
+	}
+	orgs, err := orgDashboards(ctx, config, apiKey, apiURL)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	if config.dashboardName == "" {
+		display := func() { ui.DisplayOrgs(orgs) }
+		switch {
+		case *config.jsonFlag:
+			outputJSON(orgs)
+		case *config.svgFlag != "":
+			writeScreenSVG(*config.svgFlag, display)
+		case *config.pngFlag != "":
+			writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+		default:
+			display()
+		}
+		return
+	}
+
+	org, dashboard := findDashboard(orgs, config.dashboardName)
+	startDate, endDate, heading := summaryDates(config, "7d") // like project: a sprint, not a day
+	members, err := fetchDashboardMembers(ctx, apiKey, apiURL, org.ID, dashboard.ID, *config.timeoutFlag)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+	totals := []ui.MemberTotal{}
+	var summaries []*types.SummaryResponse
+	for _, member := range members.Data {
+		data, err := fetchMemberSummary(ctx, apiKey, apiURL, org.ID, dashboard.ID, member.ID, startDate, endDate, *config.timeoutFlag)
+		if err != nil {
+			if ctx.Err() != nil {
+				ui.Errorln(err.Error()) // interrupted or past --deadline, not the member's fault
+			}
+			ui.Warnln("%s: %s (skipped)", cmp.Or(member.FullName, member.Username), err.Error())
+			continue
+		}
+		totals = append(totals, ui.NewMemberTotal(member, data))
+		summaries = append(summaries, data)
+	}
+	combined := ui.CombineSummaries(summaries)
+
+	display := func() {
+		ui.DisplayMembers(totals, dashboard.Name, heading)
+		switch {
+		case *config.dailyFlag:
+			ui.DisplayBreakdown(combined.Data, heading)
+		case *config.heatmapFlag:
+			ui.DisplayHeatmap(combined.Data, heading)
+		default:
+			ui.DisplaySummary(combined, *config.fullFlag, heading)
+		}
+	}
+	switch {
+	case *config.jsonFlag:
+		outputJSON(dashboardOutput{Org: org, Dashboard: dashboard, Range: heading, Start: startDate, End: endDate, Members: totals, Summary: combined})
+	case config.template != nil || csvComma(config) != 0 || *config.markdownFlag || *config.htmlFlag != "":
+		outputSummary(config, combined, heading)
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
+
+// orgDashboards fetches the user's organizations (only <org>, if given) with their dashboards.
+func orgDashboards(ctx context.Context, config Config, apiKey, apiURL string) ([]ui.OrgDashboards, error) {
+	orgs, err := fetchOrgs(ctx, apiKey, apiURL, *config.timeoutFlag)
+	if err != nil {
+		return nil, err
+	}
+	list := []ui.OrgDashboards{}
+	for _, org := range orgs.Data {
+		if config.orgName != "" && !matchesNameOrID(config.orgName, org.Name, org.ID) {
+			continue
+		}
+		dashboards, err := fetchDashboards(ctx, apiKey, apiURL, org.ID, *config.timeoutFlag)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ui.OrgDashboards{Org: org, Dashboards: dashboards.Data})
+	}
+	if config.orgName != "" && len(list) == 0 {
+		ui.Errorln("No organization '%s': run wakafetch org to list yours", config.orgName)
+	}
+	return list, nil
+}
+
+// findDashboard finds a dashboard by name or ID in orgs (one org, found by orgDashboards).
+func findDashboard(orgs []ui.OrgDashboards, name string) (types.Org, types.Dashboard) {
+	for _, o := range orgs {
+		for _, d := range o.Dashboards {
+			if matchesNameOrID(name, d.Name, d.ID) {
+				return o.Org, d
+			}
+		}
+	}
+	ui.Errorln("No dashboard '%s' in '%s': run wakafetch org '%s' to list them", name, orgs[0].Org.Name, orgs[0].Org.Name)
+	return types.Org{}, types.Dashboard{}
+}
+
+func matchesNameOrID(want, name, id string) bool {
+	return strings.EqualFold(want, name) || want == id
+}
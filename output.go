@@ -0,0 +1,222 @@
+package main
+
+import (
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// loadTemplate parses the --format/--template template, if any.
+func loadTemplate(config Config) *template.Template {
+	text := *config.formatFlag
+	if *config.templateFlag != "" {
+		b, err := os.ReadFile(*config.templateFlag)
+		if err != nil {
+			ui.Errorln("Failed to read template: %s", err.Error())
+		}
+		text = string(b)
+	}
+	if text == "" {
+		return nil
+	}
+	tmpl, err := ui.ParseTemplate(text)
+	if err != nil {
+		ui.Errorln("Invalid template: %s", err.Error())
+	}
+	return tmpl
+}
+
+func outputTemplate(tmpl *template.Template, view *ui.View) {
+	if err := ui.RenderTemplate(os.Stdout, tmpl, view); err != nil {
+		ui.Errorln("Failed to render template: %s", err.Error())
+	}
+}
+
+// csvComma returns the separator selected by --csv/--tsv, or 0 for neither.
+func csvComma(config Config) rune {
+	if *config.tsvFlag {
+		return '\t'
+	}
+	if *config.csvFlag {
+		return ','
+	}
+	return 0
+}
+
+func outputCSV(write func(io.Writer, *ui.View, rune) error, view *ui.View, comma rune) {
+	if err := write(os.Stdout, view, comma); err != nil {
+		ui.Errorln("Failed to write CSV: %s", err.Error())
+	}
+}
+
+func outputMarkdown(p *ui.DisplayPayload, days []types.DayData, opts ui.MarkdownOptions) {
+	if err := ui.WriteMarkdown(os.Stdout, p, days, opts); err != nil {
+		ui.Errorln("Failed to write Markdown: %s", err.Error())
+	}
+}
+
+// writeOutputFile writes with write to path, or to stdout for "-".
+func writeOutputFile(path string, write func(io.Writer) error) {
+	if path == "-" {
+		if err := write(os.Stdout); err != nil {
+			ui.Errorln("Failed to write output: %s", err.Error())
+		}
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		ui.Errorln("Failed to create %s: %s", path, err.Error())
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		ui.Errorln("Failed to write %s: %s", path, err.Error())
+	}
+	if err := file.Close(); err != nil {
+		ui.Errorln("Failed to write %s: %s", path, err.Error())
+	}
+}
+
+// writeScreenSVG captures the cards printed by display and writes them as SVG.
+func writeScreenSVG(path string, display func()) {
+	screen := ui.Capture(display)
+	if screen.Empty() {
+		return // display already warned about missing data
+	}
+	writeOutputFile(path, func(w io.Writer) error {
+		return screen.WriteSVG(w, ui.CurrentTheme())
+	})
+}
+
+// writeScreenPNG captures the cards printed by display and rasterizes them to a PNG.
+func writeScreenPNG(path string, scale int, display func()) {
+	screen := ui.Capture(display)
+	if screen.Empty() {
+		return // display already warned about missing data
+	}
+	writeOutputFile(path, func(w io.Writer) error {
+		return screen.WritePNG(w, ui.CurrentTheme(), scale)
+	})
+}
+
+// outputStats prints /stats data in the format selected by flags (cards by default).
+func outputStats(config Config, data *types.StatsResponse, rangeStr string) {
+	if data.StillComputing() {
+		hint := "use --wait to wait for the rest"
+		switch {
+		case data.Data.IsStuck:
+			hint = "WakaTime reports them as stuck"
+		case *config.waitFlag:
+			hint = "--deadline ran out"
+		}
+		ui.Warnln("Partial stats: WakaTime has calculated %d%% of them so far (%s)", data.Data.PercentCalculated, hint)
+	}
+
+	if *config.jsonFlag {
+		outputJSON(data)
+		return
+	}
+
+	if config.template != nil {
+		outputTemplate(config.template, ui.NewStatsView(data, rangeStr))
+		return
+	}
+
+	if comma := csvComma(config); comma != 0 {
+		outputCSV(ui.WriteItemsCSV, ui.NewStatsView(data, rangeStr), comma)
+		return
+	}
+
+	if *config.markdownFlag {
+		outputMarkdown(ui.NewStatsPayload(data, *config.fullFlag, rangeStr), nil, ui.MarkdownOptions{})
+		return
+	}
+
+	if *config.svgFlag != "" {
+		writeScreenSVG(*config.svgFlag, func() { ui.DisplayStats(data, *config.fullFlag, rangeStr) })
+		return
+	}
+
+	if *config.pngFlag != "" {
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, func() { ui.DisplayStats(data, *config.fullFlag, rangeStr) })
+		return
+	}
+
+	ui.DisplayStats(data, *config.fullFlag, rangeStr)
+}
+
+// outputSummary prints /summaries data in the format selected by flags (cards by default).
+func outputSummary(config Config, data *types.SummaryResponse, heading string) {
+	if *config.jsonFlag {
+		outputJSON(data)
+		return
+	}
+
+	if config.template != nil {
+		outputTemplate(config.template, ui.NewSummaryView(data, heading))
+		return
+	}
+
+	if comma := csvComma(config); comma != 0 {
+		if *config.longFlag {
+			outputCSV(ui.WriteLongCSV, ui.NewSummaryView(data, heading), comma)
+		} else {
+			outputCSV(ui.WriteDailyCSV, ui.NewSummaryView(data, heading), comma)
+		}
+		return
+	}
+
+	if *config.markdownFlag {
+		opts := ui.MarkdownOptions{Daily: *config.dailyFlag, Heatmap: *config.heatmapFlag}
+		outputMarkdown(ui.NewSummaryPayload(data, *config.fullFlag, heading), data.Data, opts)
+		return
+	}
+
+	if *config.htmlFlag != "" {
+		writeOutputFile(*config.htmlFlag, func(w io.Writer) error {
+			return ui.WriteHTML(w, data, heading, ui.CurrentTheme())
+		})
+		return
+	}
+
+	if *config.svgFlag != "" {
+		switch {
+		case *config.heatmapFlag:
+			writeOutputFile(*config.svgFlag, func(w io.Writer) error {
+				return ui.WriteHeatmapSVG(w, data.Data, heading, ui.CurrentTheme())
+			})
+		case *config.dailyFlag:
+			writeScreenSVG(*config.svgFlag, func() { ui.DisplayBreakdown(data.Data, heading) })
+		default:
+			writeScreenSVG(*config.svgFlag, func() { ui.DisplaySummary(data, *config.fullFlag, heading) })
+		}
+		return
+	}
+
+	if *config.pngFlag != "" {
+		scale := *config.scaleFlag
+		switch {
+		case *config.heatmapFlag:
+			writeScreenPNG(*config.pngFlag, scale, func() { ui.DisplayHeatmap(data.Data, heading) })
+		case *config.dailyFlag:
+			writeScreenPNG(*config.pngFlag, scale, func() { ui.DisplayBreakdown(data.Data, heading) })
+		default:
+			writeScreenPNG(*config.pngFlag, scale, func() { ui.DisplaySummary(data, *config.fullFlag, heading) })
+		}
+		return
+	}
+
+	if *config.dailyFlag {
+		ui.DisplayBreakdown(data.Data, heading)
+		return
+	}
+
+	if *config.heatmapFlag {
+		ui.DisplayHeatmap(data.Data, heading)
+		return
+	}
+
+	ui.DisplaySummary(data, *config.fullFlag, heading)
+}
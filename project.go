@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// runProject shows one project over --range (default: 7d) or --days, from /summaries
+// filtered by the project. --daily, --heatmap and the file/text outputs work as usual,
+// on the project's data only.
+func runProject(ctx context.Context, config Config, apiKey, apiURL string) {
+	startDate, endDate, heading := summaryDates(config, "7d") // today is a short look at a project
+	data, err := fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, &client.SummariesOptions{Project: config.projectName}, *config.timeoutFlag)
+	if err != nil {
+		ui.Errorln(err.Error())
+	}
+
+	display := func() { ui.DisplayProject(data, config.projectName, heading) }
+	switch {
+	case *config.dailyFlag || *config.heatmapFlag || *config.jsonFlag || config.template != nil ||
+		csvComma(config) != 0 || *config.markdownFlag || *config.htmlFlag != "":
+		outputSummary(config, data, heading)
+	case *config.svgFlag != "":
+		writeScreenSVG(*config.svgFlag, display)
+	case *config.pngFlag != "":
+		writeScreenPNG(*config.pngFlag, *config.scaleFlag, display)
+	default:
+		display()
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/client"
+)
+
+func TestFetchSummaryWithDates(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"data": [], "start": "2026-10-01T00:00:00Z", "end": "2026-10-07T23:59:59Z"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		opts        *client.SummariesOptions
+		wantProject string
+	}{
+		{"all projects", nil, ""},
+		{"one project", &client.SummariesOptions{Project: "my app"}, "my app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := fetchSummaryWithDates(context.Background(), "key", server.URL, "2026-10-01", "2026-10-07", tt.opts, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.Start != "2026-10-01T00:00:00Z" {
+				t.Errorf("start = %q", data.Start)
+			}
+			if query.Get("start") != "2026-10-01" || query.Get("end") != "2026-10-07" || query.Get("project") != tt.wantProject {
+				t.Errorf("query = %s, want the dates and project %q", query.Encode(), tt.wantProject)
+			}
+		})
+	}
+}
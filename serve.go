@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+// runServe serves Prometheus metrics (--prometheus) and the JSON API and badges (--listen),
+// on one server when both use the same address.
+func runServe(ctx context.Context, config Config, apiKey, apiURL string) {
+	muxes := map[string]*http.ServeMux{}
+	var addrs []string
+	muxFor := func(addr string) *http.ServeMux {
+		if muxes[addr] == nil {
+			muxes[addr] = http.NewServeMux()
+			addrs = append(addrs, addr)
+		}
+		return muxes[addr]
+	}
+
+	if addr := *config.prometheusFlag; addr != "" {
+		ranges := parseRanges(*config.rangesFlag)
+		exporter := newExporter(ranges, *config.topFlag)
+		go func() {
+			for {
+				for _, r := range ranges {
+					fetchCtx, cancel := withDeadline(ctx, config)
+					view, err := fetchRangeView(fetchCtx, config, apiKey, apiURL, r)
+					cancel()
+					if ctx.Err() != nil {
+						return // shutting down
+					}
+					if err != nil {
+						ui.Warnln("Failed to fetch %s: %s", r, err.Error())
+					}
+					exporter.update(r, view, err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(*config.intervalFlag):
+				}
+			}
+		}()
+		muxFor(addr).HandleFunc("GET /metrics", exporter.serveMetrics)
+		fmt.Printf("Serving Prometheus metrics on %s/metrics\n", addr)
+	}
+
+	if addr := *config.listenFlag; addr != "" {
+		api := &apiServer{config: config, apiKey: apiKey, apiURL: apiURL, cache: newTTLCache(*config.cacheTTLFlag)}
+		api.register(muxFor(addr))
+		fmt.Printf("Serving the API and badges on %s\n", addr)
+	}
+
+	errs := make(chan error, len(addrs))
+	var servers []*http.Server
+	for _, addr := range addrs {
+		// a client gets readHeaderTimeout to send its headers, so slow ones can't hold connections open
+		server := &http.Server{Addr: addr, Handler: muxes[addr], ReadHeaderTimeout: readHeaderTimeout}
+		servers = append(servers, server)
+		go func() { errs <- server.ListenAndServe() }()
+	}
+	select {
+	case err := <-errs:
+		ui.Errorln("Server failed: %s", err.Error())
+	case <-ctx.Done():
+		// Ctrl-C/SIGTERM: finish the requests being served, for a few seconds at most
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, server := range servers {
+			server.Shutdown(shutdownCtx)
+		}
+	}
+}
+
+// parseRanges splits a --ranges list, validating each range like --range.
+func parseRanges(s string) []string {
+	var ranges []string
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if !isValidRange(r) {
+			ui.Errorln("Invalid range in --ranges: '%s', must be one of today, yesterday, 7d, 30d, 6m, 1y, all, or a year", r)
+		}
+		ranges = append(ranges, r)
+	}
+	if len(ranges) == 0 {
+		ui.Errorln("Invalid value for --ranges: no ranges given")
+	}
+	return ranges
+}
+
+// isValidRange reports whether getRangeStr (or parseYear) accepts a --range value, without exiting.
+func isValidRange(rangeFlag string) bool {
+	if _, isYear := parseYear(rangeFlag); isYear {
+		return true
+	}
+	switch rangeFlag {
+	case "today", "yesterday", "7d", "30d", "6m", "1y", "all":
+		return true
+	}
+	return false
+}
+
+// fetchRangeView fetches a --range value the same way the default view does:
+// the Stats API, or the Summary API for years and, on WakaTime, today/yesterday.
+func fetchRangeView(ctx context.Context, config Config, apiKey, apiURL, rangeFlag string) (*ui.View, error) {
+	timeout := *config.timeoutFlag
+	if year, isYear := parseYear(rangeFlag); isYear {
+		data, err := fetchSummaryWithDates(ctx, apiKey, apiURL, fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year), nil, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return ui.NewSummaryView(data, fmt.Sprintf("Year %d", year)), nil
+	}
+	if (rangeFlag == "today" || rangeFlag == "yesterday") && isWakaTimeAPI(apiURL) {
+		startDate, endDate, heading, _ := getSummaryRange(rangeFlag)
+		data, err := fetchSummaryWithDates(ctx, apiKey, apiURL, startDate, endDate, nil, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return ui.NewSummaryView(data, heading), nil
+	}
+	rangeStr := getRangeStr(rangeFlag)
+	data, err := fetchStats(ctx, apiKey, apiURL, rangeStr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return ui.NewStatsView(data, rangeStr), nil
+}
+
+// exporter holds the latest data per range and renders it in the Prometheus text format.
+type exporter struct {
+	mu          sync.Mutex
+	ranges      []string
+	top         int
+	views       map[string]*ui.View
+	lastSuccess map[string]time.Time
+	fetches     map[string]int
+	fetchErrors map[string]int
+	scrapes     int
+}
+
+func newExporter(ranges []string, top int) *exporter {
+	return &exporter{
+		ranges:      ranges,
+		top:         top,
+		views:       map[string]*ui.View{},
+		lastSuccess: map[string]time.Time{},
+		fetches:     map[string]int{},
+		fetchErrors: map[string]int{},
+	}
+}
+
+func (e *exporter) update(rangeFlag string, view *ui.View, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fetches[rangeFlag]++
+	if err != nil {
+		e.fetchErrors[rangeFlag]++
+		return // keep serving the last good data
+	}
+	e.views[rangeFlag] = view
+	e.lastSuccess[rangeFlag] = time.Now()
+}
+
+func (e *exporter) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	e.scrapes++
+	var sb strings.Builder
+	e.writeMetrics(&sb)
+	e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	io.WriteString(w, sb.String())
+}
+
+type metricDimension struct {
+	name  string // the dimension label
+	items []ui.Item
+}
+
+// metricDimensions are the exported dimensions of v.
+// Entities (file names) are left out, they'd explode the label cardinality.
+func metricDimensions(v *ui.View) []metricDimension {
+	return []metricDimension{
+		{"language", v.Languages},
+		{"project", v.Projects},
+		{"editor", v.Editors},
+		{"os", v.OperatingSystems},
+		{"category", v.Categories},
+		{"machine", v.Machines},
+		{"branch", v.Branches},
+		{"dependency", v.Dependencies},
+	}
+}
+
+func (e *exporter) writeMetrics(sb *strings.Builder) {
+	metric := func(name, kind, help string) {
+		fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	metric("wakatime_seconds_total", "gauge", "Coding time per item in the range, the top items per dimension and \"Other\".")
+	for _, r := range e.ranges {
+		view := e.views[r]
+		if view == nil {
+			continue
+		}
+		for _, dim := range metricDimensions(view) {
+			for _, item := range topMetricItems(dim.items, e.top) {
+				fmt.Fprintf(sb, "wakatime_seconds_total{range=%s,dimension=%s,name=%s} %g\n",
+					labelValue(r), labelValue(dim.name), labelValue(item.Name), item.Seconds)
+			}
+		}
+	}
+
+	metric("wakatime_range_seconds", "gauge", "Total coding time in the range.")
+	e.eachView(func(r string, v *ui.View) {
+		fmt.Fprintf(sb, "wakatime_range_seconds{range=%s} %g\n", labelValue(r), v.TotalSeconds)
+	})
+
+	metric("wakatime_daily_average_seconds", "gauge", "Daily average coding time in the range.")
+	e.eachView(func(r string, v *ui.View) {
+		fmt.Fprintf(sb, "wakatime_daily_average_seconds{range=%s} %g\n", labelValue(r), v.DailyAverageSeconds)
+	})
+
+	metric("wakatime_last_success_timestamp_seconds", "gauge", "Unix time of the last successful fetch of the range.")
+	e.eachView(func(r string, v *ui.View) {
+		fmt.Fprintf(sb, "wakatime_last_success_timestamp_seconds{range=%s} %d\n", labelValue(r), e.lastSuccess[r].Unix())
+	})
+
+	metric("wakatime_fetches_total", "counter", "API fetches of the range.")
+	for _, r := range e.ranges {
+		fmt.Fprintf(sb, "wakatime_fetches_total{range=%s} %d\n", labelValue(r), e.fetches[r])
+	}
+
+	metric("wakatime_fetch_errors_total", "counter", "Failed API fetches of the range.")
+	for _, r := range e.ranges {
+		fmt.Fprintf(sb, "wakatime_fetch_errors_total{range=%s} %d\n", labelValue(r), e.fetchErrors[r])
+	}
+
+	metric("wakatime_scrapes_total", "counter", "Scrapes of this exporter.")
+	fmt.Fprintf(sb, "wakatime_scrapes_total %d\n", e.scrapes)
+}
+
+func (e *exporter) eachView(f func(string, *ui.View)) {
+	for _, r := range e.ranges {
+		if view := e.views[r]; view != nil {
+			f(r, view)
+		}
+	}
+}
+
+// topMetricItems keeps the top n items (they're sorted by time), summing the rest into "Other".
+// WakaTime has real "Other" items (a language, a category): the rest is added to it, as two
+// series with the same labels would fail the scrape.
+func topMetricItems(items []ui.Item, n int) []ui.Item {
+	if len(items) <= n {
+		return items
+	}
+	top := append([]ui.Item(nil), items[:n]...)
+	other := -1
+	for i, item := range top {
+		if item.Name == "Other" {
+			other = i
+		}
+	}
+	if other < 0 {
+		top = append(top, ui.Item{Name: "Other"})
+		other = len(top) - 1
+	}
+	for _, item := range items[n:] {
+		top[other].Seconds += item.Seconds
+	}
+	return top
+}
+
+// labelValue quotes a Prometheus label value.
+func labelValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
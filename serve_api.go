@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// apiServer serves the JSON API and badges of `wakafetch serve --listen`.
+// Responses are cached for --cache-ttl; the API key never leaves the server.
+type apiServer struct {
+	config Config
+	apiKey string
+	apiURL string
+	cache  *ttlCache
+}
+
+func (s *apiServer) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/stats", s.handleStats)
+	mux.HandleFunc("GET /api/summary", s.handleSummary)
+	mux.HandleFunc("GET /api/heatmap.svg", s.handleHeatmap)
+	mux.HandleFunc("GET /badge/{file}", s.handleRangeBadge)
+	mux.HandleFunc("GET /badge/{dimension}/{file}", s.handleItemBadge)
+}
+
+// handleStats serves the --format view of a range as JSON: /api/stats?range=7d.
+func (s *apiServer) handleStats(w http.ResponseWriter, r *http.Request) {
+	view, err := s.view(r.Context(), queryOr(r, "range", "today"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.writeJSON(w, view)
+}
+
+// handleSummary serves the raw /summaries response of a date range: /api/summary?start=2024-01-01&end=2024-01-31.
+func (s *apiServer) handleSummary(w http.ResponseWriter, r *http.Request) {
+	start, end := r.URL.Query().Get("start"), r.URL.Query().Get("end")
+	if err := validateDates(start, end); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	data, err := s.summary(r.Context(), start, end)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.writeJSON(w, data)
+}
+
+// handleHeatmap serves the calendar heatmap as SVG: /api/heatmap.svg?range=1y&theme=light.
+// The default range is the same as --heatmap's.
+func (s *apiServer) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	defaultRange := "1y"
+	if isWakaTimeAPI(s.apiURL) {
+		defaultRange = "7d"
+	}
+	rangeFlag := queryOr(r, "range", defaultRange)
+	theme := ui.CurrentTheme()
+	if name := r.URL.Query().Get("theme"); name != "" {
+		if err := ui.ValidateTheme(name); err != nil {
+			writeAPIError(w, badRequest(err.Error()))
+			return
+		}
+		theme = ui.Themes[name]
+	}
+
+	var startDate, endDate, heading string
+	if year, isYear := parseYear(rangeFlag); isYear {
+		startDate, endDate, heading = fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year), fmt.Sprintf("Year %d", year)
+	} else {
+		var valid bool
+		startDate, endDate, heading, valid = getSummaryRange(rangeFlag)
+		if !valid {
+			writeAPIError(w, badRequest("invalid range: use today, yesterday, 7d, 30d, 6m, 1y, or a year"))
+			return
+		}
+	}
+	data, err := s.summary(r.Context(), startDate, endDate)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ui.WriteHeatmapSVG(&buf, data.Data, heading, theme); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.setCacheHeaders(w)
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Write(buf.Bytes())
+}
+
+// handleRangeBadge serves the total of a range as a shields.io endpoint badge: /badge/today.json.
+func (s *apiServer) handleRangeBadge(w http.ResponseWriter, r *http.Request) {
+	rangeFlag, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	view, err := s.view(r.Context(), rangeFlag)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.writeBadge(w, r, strings.ToLower(view.Range), view.Total)
+}
+
+// badgeDimensions maps the dimension in /badge/{dimension}/{name}.json to its items.
+var badgeDimensions = map[string]func(*ui.View) []ui.Item{
+	"language": func(v *ui.View) []ui.Item { return v.Languages },
+	"project":  func(v *ui.View) []ui.Item { return v.Projects },
+	"editor":   func(v *ui.View) []ui.Item { return v.Editors },
+	"os":       func(v *ui.View) []ui.Item { return v.OperatingSystems },
+	"category": func(v *ui.View) []ui.Item { return v.Categories },
+	"machine":  func(v *ui.View) []ui.Item { return v.Machines },
+}
+
+// handleItemBadge serves the time of one item as a shields.io endpoint badge:
+// /badge/language/Go.json?range=7d (default range: 7d).
+func (s *apiServer) handleItemBadge(w http.ResponseWriter, r *http.Request) {
+	items, ok := badgeDimensions[r.PathValue("dimension")]
+	name, isJSON := strings.CutSuffix(r.PathValue("file"), ".json")
+	if !ok || !isJSON {
+		http.NotFound(w, r)
+		return
+	}
+	view, err := s.view(r.Context(), queryOr(r, "range", "7d"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	message := ui.FormatDuration(0)
+	for _, item := range items(view) {
+		if strings.EqualFold(item.Name, name) {
+			name, message = item.Name, item.Time
+			break
+		}
+	}
+	s.writeBadge(w, r, name, message)
+}
+
+// writeBadge writes a shields.io endpoint response; ?label= and ?color= override the defaults.
+func (s *apiServer) writeBadge(w http.ResponseWriter, r *http.Request, label, message string) {
+	s.writeJSON(w, map[string]any{
+		"schemaVersion": 1,
+		"label":         queryOr(r, "label", label),
+		"message":       message,
+		"color":         queryOr(r, "color", "blue"),
+		"namedLogo":     "wakatime",
+	})
+}
+
+// view fetches a range through the cache, within --deadline.
+func (s *apiServer) view(ctx context.Context, rangeFlag string) (*ui.View, error) {
+	if !isValidRange(rangeFlag) {
+		return nil, badRequest("invalid range: use today, yesterday, 7d, 30d, 6m, 1y, all, or a year")
+	}
+	ctx, cancel := withDeadline(ctx, s.config)
+	defer cancel()
+	v, err := s.cache.get("view:"+rangeFlag, func() (any, error) {
+		return fetchRangeView(ctx, s.config, s.apiKey, s.apiURL, rangeFlag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*ui.View), nil
+}
+
+// summary fetches a date range through the cache, within --deadline.
+func (s *apiServer) summary(ctx context.Context, startDate, endDate string) (*types.SummaryResponse, error) {
+	ctx, cancel := withDeadline(ctx, s.config)
+	defer cancel()
+	v, err := s.cache.get("summary:"+startDate+":"+endDate, func() (any, error) {
+		return fetchSummaryWithDates(ctx, s.apiKey, s.apiURL, startDate, endDate, nil, *s.config.timeoutFlag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*types.SummaryResponse), nil
+}
+
+func (s *apiServer) setCacheHeaders(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(s.cache.ttl.Seconds())))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+}
+
+func (s *apiServer) writeJSON(w http.ResponseWriter, v any) {
+	s.setCacheHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// badRequest is an error caused by the request, answered with 400 instead of 502.
+type badRequest string
+
+func (e badRequest) Error() string { return string(e) }
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if _, ok := err.(badRequest); ok {
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func queryOr(r *http.Request, key, def string) string {
+	if v := r.URL.Query().Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// validateDates checks a start/end pair of YYYY-MM-DD dates, at most a year apart.
+func validateDates(start, end string) error {
+	startTime, err1 := time.Parse("2006-01-02", start)
+	endTime, err2 := time.Parse("2006-01-02", end)
+	if err1 != nil || err2 != nil {
+		return badRequest("start and end must be dates (YYYY-MM-DD)")
+	}
+	if endTime.Before(startTime) {
+		return badRequest("end is before start")
+	}
+	if endTime.After(startTime.AddDate(1, 0, 0)) {
+		return badRequest("start and end must be at most a year apart")
+	}
+	return nil
+}
+
+// ttlCache caches fetched values for ttl. Errors aren't cached. Concurrent gets of
+// a missing key share one fetch instead of each hitting the API.
+type ttlCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	entries  map[string]cacheEntry
+	inflight map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// cacheCall is a fetch in progress; done is closed once value and err are set.
+type cacheCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, entries: map[string]cacheEntry{}, inflight: map[string]*cacheCall{}}
+}
+
+// get returns the cached value for key, calling fetch when it's missing or expired.
+// While a fetch of key is in flight, other gets of key wait for its result.
+func (c *ttlCache) get(key string, fetch func() (any, error)) (any, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = fetch()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		now := time.Now()
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = cacheEntry{call.value, now.Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+func TestValidateDates(t *testing.T) {
+	tests := []struct {
+		start, end string
+		wantErr    string
+	}{
+		{"2026-01-01", "2026-01-31", ""},
+		{"2026-01-01", "2026-01-01", ""},
+		{"2025-10-19", "2026-10-19", ""},
+		{"2024-02-29", "2025-02-28", ""},
+		{"2025-10-19", "2026-10-20", "start and end must be at most a year apart"},
+		{"2026-01-31", "2026-01-01", "end is before start"},
+		{"", "2026-01-01", "start and end must be dates (YYYY-MM-DD)"},
+		{"2026-01-01", "", "start and end must be dates (YYYY-MM-DD)"},
+		{"2026-1-1", "2026-01-31", "start and end must be dates (YYYY-MM-DD)"},
+		{"2026-02-30", "2026-03-01", "start and end must be dates (YYYY-MM-DD)"},
+	}
+	for _, tt := range tests {
+		err := validateDates(tt.start, tt.end)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("validateDates(%q, %q) = %v, want nil", tt.start, tt.end, err)
+		case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+			t.Errorf("validateDates(%q, %q) = %v, want %q", tt.start, tt.end, err, tt.wantErr)
+		case err != nil:
+			if _, ok := err.(badRequest); !ok {
+				t.Errorf("validateDates(%q, %q) = %T, want a badRequest", tt.start, tt.end, err)
+			}
+		}
+	}
+}
+
+func TestTTLCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		fail      bool
+		wantCalls int
+	}{
+		{"cached", time.Hour, false, 1},
+		{"expired", -time.Second, false, 3},
+		{"errors aren't cached", time.Hour, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTTLCache(tt.ttl)
+			calls := 0
+			fetch := func() (any, error) {
+				calls++
+				if tt.fail {
+					return nil, errors.New("failed")
+				}
+				return calls, nil
+			}
+			for range 3 {
+				v, err := c.get("key", fetch)
+				if tt.fail != (err != nil) || (!tt.fail && v != calls) {
+					t.Fatalf("get() = %v, %v", v, err)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fetch was called %d times, want %d", calls, tt.wantCalls)
+			}
+			if other, _ := c.get("other", func() (any, error) { return "other", nil }); other != "other" {
+				t.Errorf("get() of another key = %v", other)
+			}
+		})
+	}
+
+	c := newTTLCache(-time.Second)
+	c.get("old", func() (any, error) { return 1, nil })
+	c.get("new", func() (any, error) { return 2, nil })
+	if _, ok := c.entries["old"]; ok {
+		t.Error("expired entries aren't removed")
+	}
+}
+
+func TestTTLCacheSingleFlight(t *testing.T) {
+	c := newTTLCache(time.Hour)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func() (any, error) {
+		calls.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]any, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.get("key", fetch)
+		}()
+	}
+	// wait until the first fetch is in flight, then give the others time to join it
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fetch was called %d times, want 1", n)
+	}
+	for i, v := range results {
+		if v != "value" {
+			t.Errorf("get() #%d = %v, want value", i, v)
+		}
+	}
+	if len(c.inflight) != 0 {
+		t.Errorf("inflight isn't cleared: %v", c.inflight)
+	}
+}
+
+// testAPIServer returns an apiServer whose cache already holds the views of 7d and today.
+func testAPIServer() *http.ServeMux {
+	deadline := time.Duration(0)
+	s := &apiServer{config: Config{deadlineFlag: &deadline}, cache: newTTLCache(time.Hour)}
+	week := &ui.View{Range: "Last 7 days", Total: "12h 30m", Breakdown: ui.Breakdown{
+		Languages: []ui.Item{{Name: "Go", Time: "8h"}, {Name: "C++", Time: "4h 30m"}},
+		Projects:  []ui.Item{{Name: "wakafetch", Time: "12h 30m"}},
+	}}
+	today := &ui.View{Range: "Today", Total: "2h"}
+	s.cache.get("view:7d", func() (any, error) { return week, nil })
+	s.cache.get("view:today", func() (any, error) { return today, nil })
+	mux := http.NewServeMux()
+	s.register(mux)
+	return mux
+}
+
+func TestBadges(t *testing.T) {
+	mux := testAPIServer()
+	tests := []struct {
+		path        string
+		wantStatus  int
+		wantLabel   string
+		wantMessage string
+		wantColor   string
+	}{
+		{"/badge/7d.json", 200, "last 7 days", "12h 30m", "blue"},
+		{"/badge/today.json?label=coding&color=green", 200, "coding", "2h", "green"},
+		{"/badge/language/Go.json", 200, "Go", "8h", "blue"},
+		{"/badge/language/go.json?range=7d", 200, "Go", "8h", "blue"},
+		{"/badge/language/c++.json", 200, "C++", "4h 30m", "blue"},
+		{"/badge/project/wakafetch.json", 200, "wakafetch", "12h 30m", "blue"},
+		{"/badge/language/Rust.json", 200, "Rust", ui.FormatDuration(0), "blue"},
+		{"/badge/7d.svg", 404, "", "", ""},
+		{"/badge/planet/Go.json", 404, "", "", ""},
+		{"/badge/language/Go.svg", 404, "", "", ""},
+		{"/badge/bogus.json", 400, "", "", ""},
+		{"/badge/language/Go.json?range=bogus", 400, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != 200 {
+				return
+			}
+			var badge struct {
+				SchemaVersion         int
+				Label, Message, Color string
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &badge); err != nil {
+				t.Fatal(err)
+			}
+			if badge.SchemaVersion != 1 || badge.Label != tt.wantLabel || badge.Message != tt.wantMessage || badge.Color != tt.wantColor {
+				t.Errorf("badge = %+v, want %q %q %q", badge, tt.wantLabel, tt.wantMessage, tt.wantColor)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "max-age=3600" {
+				t.Errorf("Cache-Control = %q", got)
+			}
+		})
+	}
+}
+
+func TestSummaryBadRequest(t *testing.T) {
+	mux := testAPIServer()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/api/summary?start=2026-02-01&end=2026-01-01", nil))
+	if rec.Code != http.StatusBadRequest || !json.Valid(rec.Body.Bytes()) {
+		t.Errorf("status = %d, body = %s, want a 400 JSON error", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+func TestTopMetricItems(t *testing.T) {
+	items := []ui.Item{{Name: "Go", Seconds: 300}, {Name: "Python", Seconds: 200}, {Name: "Rust", Seconds: 100}, {Name: "C", Seconds: 50}}
+	tests := []struct {
+		name string
+		n    int
+		want []ui.Item
+	}{
+		{"all fit", 4, items},
+		{"more than enough", 10, items},
+		{"top 2", 2, []ui.Item{{Name: "Go", Seconds: 300}, {Name: "Python", Seconds: 200}, {Name: "Other", Seconds: 150}}},
+		{"top 0", 0, []ui.Item{{Name: "Other", Seconds: 650}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topMetricItems(items, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("topMetricItems(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+	withOther := []ui.Item{{Name: "Go", Seconds: 300}, {Name: "Other", Seconds: 200}, {Name: "Rust", Seconds: 100}, {Name: "C", Seconds: 50}}
+	want := []ui.Item{{Name: "Go", Seconds: 300}, {Name: "Other", Seconds: 350}}
+	if got := topMetricItems(withOther, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("topMetricItems() with a real Other = %v, want %v", got, want)
+	}
+	if withOther[1].Seconds != 200 {
+		t.Errorf("topMetricItems modified its input: %v", withOther)
+	}
+	if items[2].Name != "Rust" {
+		t.Errorf("topMetricItems modified its input: %v", items)
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := map[string]string{
+		"Go":              `"Go"`,
+		"":                `""`,
+		`say "hi"`:        `"say \"hi\""`,
+		`C:\src`:          `"C:\\src"`,
+		"two\nlines":      `"two\nlines"`,
+		`\"`:              `"\\\""`,
+		"Visual Studio ✓": `"Visual Studio ✓"`,
+	}
+	for in, want := range tests {
+		if got := labelValue(in); got != want {
+			t.Errorf("labelValue(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
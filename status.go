@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+var statusPresets = []string{"plain", "tmux", "polybar", "i3blocks", "waybar", "starship"}
+
+func isStatusPreset(name string) bool {
+	for _, p := range statusPresets {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// statusData is what the status line shows, cached between runs in statusCachePath.
+type statusData struct {
+	APIURL       string    `json:"api_url"`
+	KeyHash      string    `json:"key_hash"` // of the API key, so another account's stats aren't shown
+	Date         string    `json:"date"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	TotalSeconds float64   `json:"total_seconds"`
+	TopLanguage  string    `json:"top_language"`
+	TopProject   string    `json:"top_project"`
+}
+
+// runStatus prints today's total as a single line in the format of --preset.
+// It's meant to run every few seconds, so today's stats are reused for --cache-ttl,
+// and a failed fetch falls back to the cached stats when they're from today.
+func runStatus(ctx context.Context, config Config, apiKey, apiURL string) {
+	today := time.Now().Format("2006-01-02")
+	keyHash := apiKeyHash(apiKey)
+	cached, cacheErr := readStatusCache()
+	usable := cacheErr == nil && cached.APIURL == apiURL && cached.KeyHash == keyHash && cached.Date == today
+
+	data := cached
+	if !usable || time.Since(cached.FetchedAt) >= *config.cacheTTLFlag {
+		view, err := fetchRangeView(ctx, config, apiKey, apiURL, "today")
+		switch {
+		case err == nil:
+			data = &statusData{
+				APIURL:       apiURL,
+				KeyHash:      keyHash,
+				Date:         today,
+				FetchedAt:    time.Now(),
+				TotalSeconds: view.TotalSeconds,
+				TopLanguage:  view.TopLanguage,
+				TopProject:   view.TopProject,
+			}
+			writeStatusCache(data)
+		case usable:
+			ui.Warnln("%s (showing stats from %s)", err.Error(), cached.FetchedAt.Format("15:04"))
+		default:
+			ui.Errorln(err.Error())
+		}
+	}
+
+	fmt.Print(formatStatus(*data, *config.presetFlag, *config.targetFlag, ui.CurrentTheme()))
+}
+
+// formatStatus renders the status line for a preset, newline included.
+func formatStatus(data statusData, preset string, target time.Duration, t ui.Theme) string {
+	total := ui.FormatDuration(data.TotalSeconds)
+	percent := -1
+	if target > 0 {
+		percent = int(data.TotalSeconds / target.Seconds() * 100)
+	}
+	progressColor := t.Yellow
+	if percent >= 100 {
+		progressColor = t.Green
+	}
+
+	// parts are the total, the progress and the top language, each with an optional color
+	type part struct{ text, color string }
+	parts := []part{{total, t.Blue}}
+	if percent >= 0 {
+		parts = append(parts, part{fmt.Sprintf("%d%%", percent), progressColor})
+	}
+	if data.TopLanguage != "" {
+		parts = append(parts, part{data.TopLanguage, ""})
+	}
+	join := func(colorize func(text, color string) string) string {
+		texts := make([]string, len(parts))
+		for i, p := range parts {
+			texts[i] = p.text
+			if p.color != "" && colorize != nil {
+				texts[i] = colorize(p.text, p.color)
+			}
+		}
+		return strings.Join(texts, " · ")
+	}
+
+	switch preset {
+	case "tmux":
+		return join(func(text, color string) string { return "#[fg=" + color + "]" + text + "#[default]" }) + "\n"
+	case "polybar":
+		return join(func(text, color string) string { return "%{F" + color + "}" + text + "%{F-}" }) + "\n"
+	case "i3blocks":
+		// full text, short text, color
+		color := t.Foreground
+		if percent >= 0 {
+			color = progressColor
+		}
+		return join(nil) + "\n" + total + "\n" + color + "\n"
+	case "waybar":
+		class := "idle"
+		switch {
+		case percent >= 100:
+			class = "done"
+		case data.TotalSeconds > 0:
+			class = "active"
+		}
+		tooltip := []string{"Today: " + total}
+		if percent >= 0 {
+			tooltip = append(tooltip, fmt.Sprintf("Target: %s (%d%%)", ui.FormatDuration(target.Seconds()), percent))
+		}
+		if data.TopLanguage != "" {
+			tooltip = append(tooltip, "Top language: "+data.TopLanguage)
+		}
+		if data.TopProject != "" {
+			tooltip = append(tooltip, "Top project: "+data.TopProject)
+		}
+		out := map[string]any{"text": join(nil), "tooltip": strings.Join(tooltip, "\n"), "class": class}
+		if percent >= 0 {
+			out["percentage"] = min(percent, 100)
+		}
+		b, _ := json.Marshal(out)
+		return string(b) + "\n"
+	case "starship":
+		// nothing at all hides the module until there's activity today
+		if data.TotalSeconds == 0 {
+			return ""
+		}
+		return join(nil) + "\n"
+	default:
+		return join(nil) + "\n"
+	}
+}
+
+// apiKeyHash identifies an account in the cache without writing its API key to disk.
+func apiKeyHash(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func statusCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "wakafetch", "status.json"), nil
+}
+
+func readStatusCache() (*statusData, error) {
+	path, err := statusCachePath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var data statusData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// writeStatusCache saves data for the next run; failing to is not worth an error in a status bar.
+func writeStatusCache(data *statusData) {
+	path, err := statusCachePath()
+	if err != nil {
+		return
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	os.WriteFile(path, b, 0o600)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+func TestFormatStatus(t *testing.T) {
+	coding := statusData{TotalSeconds: 9000, TopLanguage: "Go", TopProject: "wakafetch"}
+	tests := []struct {
+		name   string
+		data   statusData
+		preset string
+		target time.Duration
+		want   string
+	}{
+		{"plain", coding, "plain", 4 * time.Hour, "2h 30m · 62% · Go\n"},
+		{"plain without target", coding, "plain", 0, "2h 30m · Go\n"},
+		{"tmux", coding, "tmux", 4 * time.Hour, "#[fg=#2472c8]2h 30m#[default] · #[fg=#e5e510]62%#[default] · Go\n"},
+		{"tmux, target reached", statusData{TotalSeconds: 18000}, "tmux", 4 * time.Hour, "#[fg=#2472c8]5h 0m#[default] · #[fg=#0dbc79]125%#[default]\n"},
+		{"polybar", coding, "polybar", 4 * time.Hour, "%{F#2472c8}2h 30m%{F-} · %{F#e5e510}62%%{F-} · Go\n"},
+		{"i3blocks", coding, "i3blocks", 4 * time.Hour, "2h 30m · 62% · Go\n2h 30m\n#e5e510\n"},
+		{"i3blocks without target", coding, "i3blocks", 0, "2h 30m · Go\n2h 30m\n#d4d4d4\n"},
+		{"waybar", coding, "waybar", 4 * time.Hour, `{"class":"active","percentage":62,"text":"2h 30m · 62% · Go","tooltip":"Today: 2h 30m\nTarget: 4h 0m (62%)\nTop language: Go\nTop project: wakafetch"}` + "\n"},
+		{"waybar, target reached", statusData{TotalSeconds: 18000}, "waybar", 4 * time.Hour, `{"class":"done","percentage":100,"text":"5h 0m · 125%","tooltip":"Today: 5h 0m\nTarget: 4h 0m (125%)"}` + "\n"},
+		{"waybar, idle", statusData{}, "waybar", 0, `{"class":"idle","text":"0m 0s","tooltip":"Today: 0m 0s"}` + "\n"},
+		{"starship", coding, "starship", 0, "2h 30m · Go\n"},
+		{"starship, idle", statusData{}, "starship", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStatus(tt.data, tt.preset, tt.target, ui.Themes["dark"]); got != tt.want {
+				t.Errorf("formatStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHash(t *testing.T) {
+	if apiKeyHash("key-a") == apiKeyHash("key-b") {
+		t.Error("different API keys have the same hash")
+	}
+	if apiKeyHash("key-a") != apiKeyHash("key-a") {
+		t.Error("the same API key has different hashes")
+	}
+}
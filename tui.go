@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+type tuiView int
+
+const (
+	tuiOverview tuiView = iota
+	tuiDaily
+	tuiHeatmap
+	tuiDay // drill-down into the selected day
+)
+
+var tuiViewNames = []string{"Overview", "Daily", "Heatmap"}
+
+// tuiRanges are the ranges [ and ] switch between.
+var tuiRanges = []string{"today", "7d", "30d", "6m", "1y"}
+
+type tuiRangeData struct {
+	data    *types.SummaryResponse
+	heading string
+	err     error
+}
+
+// tui is the state of `wakafetch tui`. Everything is drawn with the card views
+// and redrawn in place like --watch does, after every key.
+type tui struct {
+	ctx      context.Context // cancelled by Ctrl-C/SIGTERM
+	config   Config
+	apiKey   string
+	apiURL   string
+	ranges   map[string]*tuiRangeData
+	rangeIdx int
+	view     tuiView
+	prevView tuiView // where Esc goes back to from tuiDay
+	selected string  // selected day, YYYY-MM-DD
+	card     int     // selected card the compact view starts at, rotated by c
+	full     bool
+}
+
+func runTUI(ctx context.Context, config Config, apiKey, apiURL string) {
+	if file, err := os.Stdin.Stat(); err != nil || file.Mode()&os.ModeCharDevice == 0 {
+		ui.Errorln("wakafetch tui needs an interactive terminal")
+	}
+	restoreTerminal, err := enableCbreak()
+	if err != nil {
+		ui.Errorln("Couldn't set up the terminal (stty): %s", err.Error())
+	}
+
+	t := &tui{ctx: ctx, config: config, apiKey: apiKey, apiURL: apiURL, ranges: map[string]*tuiRangeData{}, full: *config.fullFlag}
+	// start on --range when it's one of tuiRanges, else on 7d: a single day isn't much to explore
+	t.rangeIdx = 1
+	if i := slices.Index(tuiRanges, *config.rangeFlag); i > 0 {
+		t.rangeIdx = i
+	}
+
+	fmt.Print(enterAltScreen)
+	defer func() {
+		fmt.Print(leaveAltScreen)
+		restoreTerminal()
+	}()
+
+	resize := make(chan os.Signal, 1)
+	if len(resizeSignals) > 0 {
+		signal.Notify(resize, resizeSignals...)
+	}
+	keys := make(chan string)
+	go readKeys(keys)
+
+	for {
+		t.draw()
+		select {
+		case key := <-keys:
+			if !t.handleKey(key) {
+				return
+			}
+		case <-resize:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// current returns the data of the selected range, fetching it on first use.
+func (t *tui) current() *tuiRangeData {
+	r := tuiRanges[t.rangeIdx]
+	if d, ok := t.ranges[r]; ok {
+		return d
+	}
+	writeWatchFrame("Loading "+r+"...", "")
+	startDate, endDate, heading, _ := getSummaryRange(r)
+	ctx, cancel := withDeadline(t.ctx, t.config)
+	defer cancel()
+	data, err := fetchSummaryWithDates(ctx, t.apiKey, t.apiURL, startDate, endDate, nil, *t.config.timeoutFlag)
+	d := &tuiRangeData{data: data, heading: heading, err: err}
+	t.ranges[r] = d
+	if err == nil && !slices.Contains(t.dates(), t.selected) {
+		t.selected = ""
+		if dates := t.dates(); len(dates) > 0 {
+			t.selected = dates[len(dates)-1]
+		}
+	}
+	return d
+}
+
+// dates are the days of the selected range, oldest first.
+func (t *tui) dates() []string {
+	d := t.ranges[tuiRanges[t.rangeIdx]]
+	if d == nil || d.data == nil {
+		return nil
+	}
+	dates := make([]string, 0, len(d.data.Data))
+	for _, day := range d.data.Data {
+		dates = append(dates, ui.DatePart(day.Range.Start))
+	}
+	return dates
+}
+
+func (t *tui) selectedDay() (types.DayData, bool) {
+	d := t.current()
+	if d.data == nil {
+		return types.DayData{}, false
+	}
+	for _, day := range d.data.Data {
+		if ui.DatePart(day.Range.Start) == t.selected {
+			return day, true
+		}
+	}
+	return types.DayData{}, false
+}
+
+func (t *tui) draw() {
+	d := t.current()
+
+	var header strings.Builder
+	header.WriteString(ui.Clr.Bold + "wakafetch" + ui.Clr.Reset + "  ")
+	for i, name := range tuiViewNames {
+		label := fmt.Sprintf(" %d %s ", i+1, name)
+		if tuiView(i) == t.view || (t.view == tuiDay && tuiView(i) == t.prevView) {
+			label = ui.Clr.Bold + ui.Clr.Blue + "[" + label[1:len(label)-1] + "]" + ui.Clr.Reset
+		}
+		header.WriteString(label)
+	}
+	header.WriteString("   " + ui.Clr.Green + d.heading + ui.Clr.Reset + "\n\n")
+
+	body := ui.CaptureText(func() {
+		if d.err != nil {
+			fmt.Fprintln(os.Stdout) // keep the frame non-empty
+			ui.Warnln("%s (press u to retry)", d.err.Error())
+			return
+		}
+		switch t.view {
+		case tuiOverview:
+			ui.DisplayOverview(d.data, t.full, d.heading, t.card)
+		case tuiDaily:
+			ui.DisplayDailySelected(d.data.Data, d.heading, t.selected)
+		case tuiHeatmap:
+			ui.DisplayHeatmapSelected(d.data.Data, d.heading, t.selected)
+		case tuiDay:
+			if day, ok := t.selectedDay(); ok {
+				ui.DisplayDay(day, t.full, t.card)
+			}
+		}
+	})
+
+	var help string
+	switch t.view {
+	case tuiOverview:
+		help = ui.TUIHelp("c", "next card ("+ui.CurrentCard(t.card)+")", "f", "full")
+	case tuiDaily:
+		help = ui.TUIHelp("↑↓", "day", "enter", "details")
+	case tuiHeatmap:
+		help = ui.TUIHelp("←↑↓→", "day", "enter", "details")
+	case tuiDay:
+		help = ui.TUIHelp("←→", "day", "c", "next card ("+ui.CurrentCard(t.card)+")", "f", "full", "esc", "back")
+	}
+	help += "\n" + ui.TUIHelp("tab/1-3", "view", "[ ]", "range", "u", "refresh", "q", "quit")
+	writeWatchFrame(header.String()+body, help)
+}
+
+// handleKey applies a key, reporting false to quit.
+func (t *tui) handleKey(key string) bool {
+	switch key {
+	case "q", "ctrl-c":
+		return false
+	case "tab":
+		t.setView((t.baseView() + 1) % tuiView(len(tuiViewNames)))
+	case "1", "2", "3":
+		t.setView(tuiView(key[0] - '1'))
+	case "[":
+		t.rangeIdx = (t.rangeIdx + len(tuiRanges) - 1) % len(tuiRanges)
+	case "]":
+		t.rangeIdx = (t.rangeIdx + 1) % len(tuiRanges)
+	case "u":
+		delete(t.ranges, tuiRanges[t.rangeIdx])
+	case "c":
+		if t.view == tuiOverview || t.view == tuiDay {
+			t.card = (t.card + 1) % ui.CardCount()
+		}
+	case "f":
+		if t.view == tuiOverview || t.view == tuiDay {
+			t.full = !t.full
+		}
+	case "enter":
+		if t.view == tuiDaily || t.view == tuiHeatmap {
+			t.prevView, t.view = t.view, tuiDay
+		}
+	case "esc", "backspace":
+		if t.view == tuiDay {
+			t.view = t.prevView
+		}
+	default:
+		t.move(key)
+	}
+	return true
+}
+
+func (t *tui) baseView() tuiView {
+	if t.view == tuiDay {
+		return t.prevView
+	}
+	return t.view
+}
+
+func (t *tui) setView(v tuiView) {
+	t.view, t.prevView = v, v
+}
+
+// move moves the selected day with the arrow (or hjkl) keys.
+func (t *tui) move(key string) {
+	d := t.current()
+	if d.data == nil {
+		return
+	}
+	dates := t.dates()
+	step := 0
+	switch t.view {
+	case tuiDaily:
+		// the table is newest first and only has the days with activity
+		dates = ui.DailyDates(d.data.Data)
+		switch key {
+		case "up", "k":
+			step = -1
+		case "down", "j":
+			step = 1
+		}
+	case tuiHeatmap:
+		// days run down the columns, HeatmapHeight days per column
+		height := ui.HeatmapHeight(d.data.Data)
+		switch key {
+		case "up", "k":
+			step = -1
+		case "down", "j":
+			step = 1
+		case "left", "h":
+			step = -height
+		case "right", "l":
+			step = height
+		}
+	case tuiDay:
+		switch key {
+		case "left", "h":
+			step = -1
+		case "right", "l":
+			step = 1
+		}
+	}
+	if step == 0 || len(dates) == 0 {
+		return
+	}
+	i := slices.Index(dates, t.selected)
+	if i < 0 {
+		t.selected = dates[0]
+		return
+	}
+	t.selected = dates[min(max(i+step, 0), len(dates)-1)]
+}
+
+// readKeys sends key presses from stdin: printable characters as is,
+// and "up", "enter", "esc", ... for the rest.
+func readKeys(keys chan<- string) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			keys <- "q"
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+func parseKeys(b []byte) []string {
+	var keys []string
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == 0x1b && i+2 < len(b) && (b[i+1] == '[' || b[i+1] == 'O'):
+			// an escape sequence: its parameters (as in "\x1b[1;5A" or "\x1b[5~") are skipped
+			// up to the final byte, which tells the arrows apart
+			end := i + 2
+			for b[i+1] == '[' && end < len(b)-1 && b[end] >= 0x20 && b[end] <= 0x3f {
+				end++
+			}
+			if name, ok := map[byte]string{'A': "up", 'B': "down", 'C': "right", 'D': "left"}[b[end]]; ok {
+				keys = append(keys, name)
+			}
+			i = end
+		case c == 0x1b:
+			keys = append(keys, "esc")
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+		case c == '\t':
+			keys = append(keys, "tab")
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+		case c == 0x03:
+			keys = append(keys, "ctrl-c")
+		case c >= 0x20 && c < 0x7f:
+			keys = append(keys, string(c))
+		}
+	}
+	return keys
+}
+
+// enableCbreak turns off line buffering and echo on the terminal, so keys
+// arrive as they're pressed. The returned func restores the previous settings.
+func enableCbreak() (func(), error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() { stty(strings.TrimSpace(state)) }, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin // stty works on its stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
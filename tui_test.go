@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"letters", "hjq", []string{"h", "j", "q"}},
+		{"arrows", "\x1b[A\x1b[B\x1b[C\x1b[D", []string{"up", "down", "right", "left"}},
+		{"application mode arrows", "\x1bOA\x1bOD", []string{"up", "left"}},
+		{"modified arrow", "\x1b[1;5Cx", []string{"right", "x"}},
+		{"other sequence", "\x1b[5~x", []string{"x"}},
+		{"esc", "\x1b", []string{"esc"}},
+		{"esc then key", "\x1bq", []string{"esc", "q"}},
+		{"enter", "\r\n", []string{"enter", "enter"}},
+		{"tab and backspace", "\t\x7f\x08", []string{"tab", "backspace", "backspace"}},
+		{"ctrl-c", "\x03", []string{"ctrl-c"}},
+		{"space and punctuation", " ?", []string{" ", "?"}},
+		{"other control", "\x01\x02", nil},
+		{"not ascii", "é", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTUINextCard(t *testing.T) {
+	defer func(cards []ui.CardSpec) { ui.Opts.Cards = cards }(ui.Opts.Cards)
+	ui.Opts.Cards = []ui.CardSpec{{Name: "languages"}, {Name: "projects"}, {Name: "editors"}}
+	tui := &tui{view: tuiOverview}
+	for _, want := range []int{1, 2, 0, 1} {
+		tui.handleKey("c")
+		if tui.card != want {
+			t.Errorf("card after c = %d, want %d", tui.card, want)
+		}
+	}
+	tui.view = tuiDaily
+	tui.handleKey("c")
+	if tui.card != 1 {
+		t.Errorf("c in the daily view changed the card to %d", tui.card)
+	}
+	if ui.Opts.Cards[0].Name != "languages" {
+		t.Errorf("c rotated ui.Opts.Cards: %v", ui.Opts.Cards)
+	}
+}
@@ -0,0 +1,200 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// The responses keep the body they were decoded from, and encode back to it, so the
+// fields that aren't modeled (new API fields, backend extras) survive a round trip.
+// The modeled fields changed since decoding (e.g. IsUpToDate set by the client on a 202)
+// are patched into the body. A response that wasn't decoded encodes its fields as usual.
+
+// rawJSON is what a response was decoded from.
+type rawJSON struct {
+	body    []byte
+	decoded []byte // the encoding of the modeled fields right after decoding
+}
+
+func (r *SummaryResponse) UnmarshalJSON(b []byte) error {
+	type plain SummaryResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r SummaryResponse) MarshalJSON() ([]byte, error) {
+	type plain SummaryResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *StatsResponse) UnmarshalJSON(b []byte) error {
+	type plain StatsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r StatsResponse) MarshalJSON() ([]byte, error) {
+	type plain StatsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *HeartbeatsResponse) UnmarshalJSON(b []byte) error {
+	type plain HeartbeatsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r HeartbeatsResponse) MarshalJSON() ([]byte, error) {
+	type plain HeartbeatsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *DurationsResponse) UnmarshalJSON(b []byte) error {
+	type plain DurationsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r DurationsResponse) MarshalJSON() ([]byte, error) {
+	type plain DurationsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *GoalsResponse) UnmarshalJSON(b []byte) error {
+	type plain GoalsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r GoalsResponse) MarshalJSON() ([]byte, error) {
+	type plain GoalsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *UserResponse) UnmarshalJSON(b []byte) error {
+	type plain UserResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r UserResponse) MarshalJSON() ([]byte, error) {
+	type plain UserResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *AllTimeSinceTodayResponse) UnmarshalJSON(b []byte) error {
+	type plain AllTimeSinceTodayResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r AllTimeSinceTodayResponse) MarshalJSON() ([]byte, error) {
+	type plain AllTimeSinceTodayResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *LeadersResponse) UnmarshalJSON(b []byte) error {
+	type plain LeadersResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r LeadersResponse) MarshalJSON() ([]byte, error) {
+	type plain LeadersResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *OrgsResponse) UnmarshalJSON(b []byte) error {
+	type plain OrgsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r OrgsResponse) MarshalJSON() ([]byte, error) {
+	type plain OrgsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *DashboardsResponse) UnmarshalJSON(b []byte) error {
+	type plain DashboardsResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r DashboardsResponse) MarshalJSON() ([]byte, error) {
+	type plain DashboardsResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func (r *DashboardMembersResponse) UnmarshalJSON(b []byte) error {
+	type plain DashboardMembersResponse
+	return unmarshalRaw(b, (*plain)(r), &r.raw)
+}
+
+func (r DashboardMembersResponse) MarshalJSON() ([]byte, error) {
+	type plain DashboardMembersResponse
+	return marshalRaw(r.raw, plain(r))
+}
+
+func unmarshalRaw(b []byte, v any, raw *rawJSON) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	decoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	*raw = rawJSON{body: append([]byte(nil), b...), decoded: decoded}
+	return nil
+}
+
+func marshalRaw(raw rawJSON, v any) ([]byte, error) {
+	current, err := json.Marshal(v)
+	if err != nil || raw.body == nil {
+		return current, err
+	}
+	if bytes.Equal(current, raw.decoded) {
+		return raw.body, nil
+	}
+	var body, before, after any
+	for _, d := range []struct {
+		b []byte
+		v *any
+	}{{raw.body, &body}, {raw.decoded, &before}, {current, &after}} {
+		dec := json.NewDecoder(bytes.NewReader(d.b))
+		dec.UseNumber() // numbers are kept as written
+		if err := dec.Decode(d.v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(patchJSON(body, before, after))
+}
+
+// patchJSON applies the changes from before to after onto body, keeping what body has
+// that before and after don't (the unmodeled fields).
+func patchJSON(body, before, after any) any {
+	if reflect.DeepEqual(before, after) {
+		return body
+	}
+	switch after := after.(type) {
+	case map[string]any:
+		b, ok1 := before.(map[string]any)
+		o, ok2 := body.(map[string]any)
+		if !ok1 || !ok2 {
+			return after
+		}
+		patched := make(map[string]any, len(o))
+		for k, v := range o {
+			patched[k] = v
+		}
+		for k, v := range after {
+			if !reflect.DeepEqual(b[k], v) {
+				patched[k] = patchJSON(o[k], b[k], v)
+			}
+		}
+		return patched
+	case []any:
+		b, ok1 := before.([]any)
+		o, ok2 := body.([]any)
+		if !ok1 || !ok2 || len(b) != len(after) || len(o) != len(after) {
+			return after
+		}
+		patched := make([]any, len(after))
+		for i := range after {
+			patched[i] = patchJSON(o[i], b[i], after[i])
+		}
+		return patched
+	}
+	return after
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRawRoundTrip(t *testing.T) {
+	// unmodeled fields and the key order survive (json.Marshal compacts the body)
+	body := `{"data":{"total_seconds":3600,"days_minus_holidays":5,"new_field":[1,2]},"extra":true}`
+	var stats StatsResponse
+	if err := json.Unmarshal([]byte(body), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Data.TotalSeconds != 3600 || stats.Data.DaysMinusHolidays == nil || *stats.Data.DaysMinusHolidays != 5 {
+		t.Errorf("decoded %+v", stats.Data)
+	}
+	got, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Errorf("json.Marshal() = %s, want %s", got, body)
+	}
+
+	// through a pointer, and nested in another value
+	got, err = json.Marshal(struct {
+		Stats *StatsResponse `json:"stats"`
+	}{&stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"stats":` + body + `}`; string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestRawModified(t *testing.T) {
+	body := `{"data":{"total_seconds":3600.0,"languages":[{"name":"Go","total_seconds":60,"color":"#00ADD8"}],"new_field":[1,2]},"extra":true}`
+	var stats StatsResponse
+	if err := json.Unmarshal([]byte(body), &stats); err != nil {
+		t.Fatal(err)
+	}
+	upToDate := false
+	stats.Data.IsUpToDate = &upToDate // like the client on a 202
+	stats.Data.Languages[0].Name = "Golang"
+	got, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the changes are in, the unmodeled fields are kept, and the other modeled fields
+	// aren't added with their zero values
+	want := `{"data":{"is_up_to_date":false,"languages":[{"color":"#00ADD8","name":"Golang","total_seconds":60}],"new_field":[1,2],"total_seconds":3600.0},"extra":true}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestRawNotDecoded(t *testing.T) {
+	var summary SummaryResponse
+	summary.CumulativeTotal.Seconds = 60
+	got, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back map[string]any
+	if err := json.Unmarshal(got, &back); err != nil {
+		t.Fatalf("json.Marshal() = %s: %v", got, err)
+	}
+	if total := back["cumulative_total"].(map[string]any)["seconds"]; total != 60.0 {
+		t.Errorf("cumulative_total.seconds = %v, want 60 in %s", total, got)
+	}
+}
+
+func TestRawWakapiStats(t *testing.T) {
+	var stats StatsResponse
+	if err := json.Unmarshal([]byte(`{"data": {"days_including_holidays": 7}}`), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Data.DaysMinusHolidays != nil {
+		t.Errorf("DaysMinusHolidays = %d, want nil when it isn't sent", *stats.Data.DaysMinusHolidays)
+	}
+}
+
+func TestRawDecodeError(t *testing.T) {
+	var stats StatsResponse
+	if err := json.Unmarshal([]byte(`{"data": {"total_seconds": "an hour"}}`), &stats); err == nil {
+		t.Error("json.Unmarshal() = nil, want a type error")
+	}
+	if stats.raw.body != nil {
+		t.Errorf("kept the body of a failed decode: %s", stats.raw.body)
+	}
+}
@@ -1,46 +1,63 @@
+// Package types models the responses of the WakaTime API. Wakapi's WakaTime-compatible
+// API returns the same shapes, minus the fields noted as WakaTime only.
+//
+// The response types keep their JSON body as received: encoding one again (as --json does)
+// gives back every field, including the ones not modeled here.
 package types
 
+// StatItem is one entry of a breakdown: a language, a project, an editor, ...
 type StatItem struct {
 	Name         string  `json:"name"`
 	TotalSeconds float64 `json:"total_seconds"`
-	// Seconds      float64 `json:"seconds"`
-	// Percent      float64 `json:"percent"`
-	// Digital      string  `json:"digital"`
-	// Hours        int     `json:"hours"`
-	// Minutes      int     `json:"minutes"`
-	// Text         string  `json:"text"`
+	Percent      float64 `json:"percent,omitempty"` // of the breakdown's total, 0-100
+	Digital      string  `json:"digital,omitempty"` // "4:17" (hours:minutes)
+	Decimal      string  `json:"decimal,omitempty"` // "4.28" (hours), WakaTime only
+	Text         string  `json:"text,omitempty"`    // "4 hrs 17 mins"
+	Hours        int     `json:"hours,omitempty"`
+	Minutes      int     `json:"minutes,omitempty"`
+	Seconds      int     `json:"seconds,omitempty"` // the seconds past Minutes, not a total
+	// MachineNameID is set on machines, WakaTime only.
+	MachineNameID string `json:"machine_name_id,omitempty"`
+}
+
+// GrandTotal is the total of a day in /summaries.
+type GrandTotal struct {
+	Digital      string  `json:"digital"`
+	Decimal      string  `json:"decimal"` // WakaTime only
+	Hours        int     `json:"hours"`
+	Minutes      int     `json:"minutes"`
+	Text         string  `json:"text"`
+	TotalSeconds float64 `json:"total_seconds"`
+}
+
+// SummaryRange is the day of an entry of /summaries.
+type SummaryRange struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	End      string `json:"end"`
+	Start    string `json:"start"`
+	Text     string `json:"text"`
+	Timezone string `json:"timezone"`
 }
 
 type DayData struct {
-	Entities         []StatItem `json:"entities"`
-	Branches         []StatItem `json:"branches"`
-	Categories       []StatItem `json:"categories"`
-	Dependencies     []StatItem `json:"dependencies"`
-	Editors          []StatItem `json:"editors"`
-	Languages        []StatItem `json:"languages"`
-	Machines         []StatItem `json:"machines"`
-	OperatingSystems []StatItem `json:"operating_systems"`
-	Projects         []StatItem `json:"projects"`
-	GrandTotal       struct {
-		Digital      string  `json:"digital"`
-		Hours        int     `json:"hours"`
-		Minutes      int     `json:"minutes"`
-		Text         string  `json:"text"`
-		TotalSeconds float64 `json:"total_seconds"`
-	} `json:"grand_total"`
-	Range struct {
-		Date     string `json:"date"`
-		End      string `json:"end"`
-		Start    string `json:"start"`
-		Text     string `json:"text"`
-		Timezone string `json:"timezone"`
-	} `json:"range"`
+	Entities         []StatItem   `json:"entities"`
+	Branches         []StatItem   `json:"branches"` // WakaTime: with the project parameter only
+	Categories       []StatItem   `json:"categories"`
+	Dependencies     []StatItem   `json:"dependencies"` // WakaTime only
+	Editors          []StatItem   `json:"editors"`
+	Languages        []StatItem   `json:"languages"`
+	Machines         []StatItem   `json:"machines"`
+	OperatingSystems []StatItem   `json:"operating_systems"`
+	Projects         []StatItem   `json:"projects"`
+	GrandTotal       GrandTotal   `json:"grand_total"`
+	Range            SummaryRange `json:"range"`
 }
 
 // /summaries
 type SummaryResponse struct {
 	Data            []DayData `json:"data"`
 	CumulativeTotal struct {
+		Decimal string  `json:"decimal"` // WakaTime only
 		Digital string  `json:"digital"`
 		Seconds float64 `json:"seconds"`
 		Text    string  `json:"text"`
@@ -48,37 +65,389 @@ type SummaryResponse struct {
 	DailyAverage struct {
 		DaysIncludingHolidays int     `json:"days_including_holidays"`
 		DaysMinusHolidays     int     `json:"days_minus_holidays"`
-		Holidays              int     `json:"holidays"`
+		Holidays              int     `json:"holidays"` // days without activity
 		Seconds               float64 `json:"seconds"`
 		Text                  string  `json:"text"`
+		// WakaTime only: the average with the "Other" language counted
+		SecondsIncludingOtherLanguage float64 `json:"seconds_including_other_language"`
+		TextIncludingOtherLanguage    string  `json:"text_including_other_language"`
 	} `json:"daily_average"`
 	End   string `json:"end"`
 	Start string `json:"start"`
+
+	raw rawJSON
+}
+
+// BestDay is the day with the most activity in /stats.
+type BestDay struct {
+	Date         string  `json:"date"` // YYYY-MM-DD
+	Text         string  `json:"text"`
+	TotalSeconds float64 `json:"total_seconds"`
 }
 
 // /stats
 type StatsResponse struct {
 	Data struct {
+		ID                        string     `json:"id"` // WakaTime only
 		Branches                  []StatItem `json:"branches"`
 		Categories                []StatItem `json:"categories"`
+		Dependencies              []StatItem `json:"dependencies"` // WakaTime only
 		Editors                   []StatItem `json:"editors"`
 		Languages                 []StatItem `json:"languages"`
 		Machines                  []StatItem `json:"machines"`
 		OperatingSystems          []StatItem `json:"operating_systems"`
 		Projects                  []StatItem `json:"projects"`
 		Range                     string     `json:"range"`
-		Status                    string     `json:"status"`
+		Status                    string     `json:"status"` // WakaTime: "ok", or "pending_update" while computing
 		TotalSeconds              float64    `json:"total_seconds"`
 		UserID                    string     `json:"user_id"`
 		Username                  string     `json:"username"`
 		DailyAverage              float64    `json:"daily_average"`
 		DaysIncludingHolidays     int        `json:"days_including_holidays"`
+		DaysMinusHolidays         *int       `json:"days_minus_holidays"` // WakaTime only, nil on Wakapi
+		Holidays                  int        `json:"holidays"`            // days without activity, WakaTime only
 		Start                     string     `json:"start"`
 		End                       string     `json:"end"`
+		Timezone                  string     `json:"timezone"`
+		Timeout                   int        `json:"timeout"` // keystroke timeout, minutes
+		WritesOnly                bool       `json:"writes_only"`
 		HumanReadableDailyAverage string     `json:"human_readable_daily_average"`
 		HumanReadableRange        string     `json:"human_readable_range"`
 		HumanReadableTotal        string     `json:"human_readable_total"`
 		IsCodingActivityVisible   bool       `json:"is_coding_activity_visible"`
 		IsOtherUsageVisible       bool       `json:"is_other_usage_visible"`
+		IsIncludingToday          bool       `json:"is_including_today"`
+
+		// WakaTime only: the best day, nil without activity
+		BestDay *BestDay `json:"best_day"`
+		// WakaTime only: the totals with the "Other" language counted
+		TotalSecondsIncludingOtherLanguage              float64 `json:"total_seconds_including_other_language"`
+		DailyAverageIncludingOtherLanguage              float64 `json:"daily_average_including_other_language"`
+		HumanReadableTotalIncludingOtherLanguage        string  `json:"human_readable_total_including_other_language"`
+		HumanReadableDailyAverageIncludingOtherLanguage string  `json:"human_readable_daily_average_including_other_language"`
+
+		// WakaTime computes stats in the background: until IsUpToDate, the data covers
+		// PercentCalculated% of the range. Wakapi computes them on request and leaves
+		// IsUpToDate nil; see StillComputing.
+		IsUpToDate              *bool  `json:"is_up_to_date"`
+		IsUpToDatePendingFuture bool   `json:"is_up_to_date_pending_future"`
+		IsStuck                 bool   `json:"is_stuck"`
+		IsAlreadyUpdating       bool   `json:"is_already_updating"`
+		PercentCalculated       int    `json:"percent_calculated"`
+		CreatedAt               string `json:"created_at"`
+		ModifiedAt              string `json:"modified_at"`
 	} `json:"data"`
+
+	raw rawJSON
+}
+
+// StillComputing reports whether the stats are partial because WakaTime is still computing them.
+func (r *StatsResponse) StillComputing() bool {
+	return r.Data.IsUpToDate != nil && !*r.Data.IsUpToDate
+}
+
+// /heartbeats
+type Heartbeat struct {
+	ID            string   `json:"id"`
+	Entity        string   `json:"entity"`
+	Type          string   `json:"type"` // file, app or domain
+	Category      string   `json:"category"`
+	Time          float64  `json:"time"` // unix seconds
+	Project       string   `json:"project"`
+	Branch        string   `json:"branch"`
+	Language      string   `json:"language"`
+	Dependencies  []string `json:"dependencies"`
+	IsWrite       bool     `json:"is_write"`
+	Lines         int      `json:"lines"`
+	LineNo        int      `json:"lineno"`
+	CursorPos     int      `json:"cursorpos"`
+	UserID        string   `json:"user_id"`
+	UserAgentID   string   `json:"user_agent_id"`   // see /user_agents; Wakapi: the user agent itself
+	MachineNameID string   `json:"machine_name_id"` // see /machine_names; Wakapi: the machine name itself
+	CreatedAt     string   `json:"created_at"`
+}
+
+type HeartbeatsResponse struct {
+	Data     []Heartbeat `json:"data"`
+	Start    string      `json:"start"`
+	End      string      `json:"end"`
+	Timezone string      `json:"timezone"`
+
+	raw rawJSON
+}
+
+// /durations
+type Duration struct {
+	Project  string  `json:"project"`
+	Time     float64 `json:"time"`     // unix seconds
+	Duration float64 `json:"duration"` // seconds
+	Color    string  `json:"color,omitempty"`
+	// With slice_by, the value the duration is split by.
+	Entity       string   `json:"entity,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	OS           string   `json:"os,omitempty"`
+	Editor       string   `json:"editor,omitempty"`
+	Category     string   `json:"category,omitempty"`
+	Machine      string   `json:"machine,omitempty"`
+}
+
+type DurationsResponse struct {
+	Data     []Duration `json:"data"`
+	Branches []string   `json:"branches"`
+	Start    string     `json:"start"`
+	End      string     `json:"end"`
+	Timezone string     `json:"timezone"`
+
+	raw rawJSON
+}
+
+// /goals
+type GoalRange struct {
+	Date     string `json:"date"`
+	End      string `json:"end"`
+	Start    string `json:"start"`
+	Text     string `json:"text"`
+	Timezone string `json:"timezone"`
+}
+
+// GoalChartData is the progress of a goal in one of its periods (a day or a week).
+type GoalChartData struct {
+	ActualSeconds     float64   `json:"actual_seconds"`
+	ActualSecondsText string    `json:"actual_seconds_text"`
+	GoalSeconds       float64   `json:"goal_seconds"`
+	GoalSecondsText   string    `json:"goal_seconds_text"`
+	Range             GoalRange `json:"range"`
+	RangeStatus       string    `json:"range_status"` // success, fail, pending or ignored
+	RangeStatusReason string    `json:"range_status_reason"`
+}
+
+type Goal struct {
+	ID               string          `json:"id"`
+	Title            string          `json:"title"`
+	Type             string          `json:"type"`  // coding
+	Delta            string          `json:"delta"` // day or week
+	Seconds          float64         `json:"seconds"`
+	Status           string          `json:"status"`
+	AverageStatus    string          `json:"average_status"`
+	CumulativeStatus string          `json:"cumulative_status"`
+	IsEnabled        bool            `json:"is_enabled"`
+	IsInverse        bool            `json:"is_inverse"`
+	IsSnoozed        bool            `json:"is_snoozed"`
+	IgnoreDays       []string        `json:"ignore_days"`
+	IgnoreZeroDays   bool            `json:"ignore_zero_days"`
+	Languages        []string        `json:"languages"`
+	Projects         []string        `json:"projects"`
+	Editors          []string        `json:"editors"`
+	RangeText        string          `json:"range_text"`
+	ChartData        []GoalChartData `json:"chart_data"`
+	CreatedAt        string          `json:"created_at"`
+	ModifiedAt       string          `json:"modified_at"`
+}
+
+type GoalsResponse struct {
+	Data       []Goal `json:"data"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+
+	raw rawJSON
+}
+
+// /user_agents, WakaTime only
+type UserAgent struct {
+	ID                 string `json:"id"`
+	Value              string `json:"value"` // the whole User-Agent header
+	Editor             string `json:"editor"`
+	Version            string `json:"version"` // of the plugin
+	OS                 string `json:"os"`
+	IsBrowserExtension bool   `json:"is_browser_extension"`
+	IsDesktopApp       bool   `json:"is_desktop_app"`
+	LastSeenAt         string `json:"last_seen_at"`
+	CreatedAt          string `json:"created_at"`
+}
+
+type UserAgentsResponse struct {
+	Data []UserAgent `json:"data"`
+}
+
+// /machine_names, WakaTime only
+type MachineName struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	IP         string `json:"ip"`
+	LastSeenAt string `json:"last_seen_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type MachineNamesResponse struct {
+	Data []MachineName `json:"data"`
+}
+
+// /users/current
+type User struct {
+	ID                  string `json:"id"`
+	Username            string `json:"username"`
+	DisplayName         string `json:"display_name"`
+	FullName            string `json:"full_name"`
+	Email               string `json:"email"`
+	Photo               string `json:"photo"` // URL of the avatar
+	Timezone            string `json:"timezone"`
+	Timeout             int    `json:"timeout"` // keystroke timeout, minutes
+	WritesOnly          bool   `json:"writes_only"`
+	Plan                string `json:"plan"` // "basic", "premium", ...; Wakapi leaves it empty
+	HasPremiumFeatures  bool   `json:"has_premium_features"`
+	Location            string `json:"location"`
+	Website             string `json:"website"`
+	ProfileURL          string `json:"profile_url"` // WakaTime only
+	GithubUsername      string `json:"github_username"`
+	LastHeartbeatAt     string `json:"last_heartbeat_at"`
+	LastPluginName      string `json:"last_plugin_name"`
+	LastProject         string `json:"last_project"`
+	LastBranch          string `json:"last_branch"`
+	CreatedAt           string `json:"created_at"`
+	ModifiedAt          string `json:"modified_at"`
+	IsHireable          bool   `json:"is_hireable"`
+	IsEmailPublic       bool   `json:"is_email_public"`
+	PhotoPublic         bool   `json:"photo_public"`
+	LoggedTimePublic    bool   `json:"logged_time_public"`
+	LanguagesUsedPublic bool   `json:"languages_used_public"`
+}
+
+type UserResponse struct {
+	Data User `json:"data"`
+
+	raw rawJSON
+}
+
+// /all_time_since_today: the total since the account was created
+type AllTimeSinceTodayResponse struct {
+	Data struct {
+		TotalSeconds      float64 `json:"total_seconds"`
+		DailyAverage      float64 `json:"daily_average"` // WakaTime only
+		Text              string  `json:"text"`
+		Digital           string  `json:"digital"`
+		Decimal           string  `json:"decimal"`
+		IsUpToDate        bool    `json:"is_up_to_date"`
+		PercentCalculated int     `json:"percent_calculated"`
+		Timeout           int     `json:"timeout"`
+		Range             struct {
+			Start     string `json:"start"`
+			StartDate string `json:"start_date"` // YYYY-MM-DD, the first day with activity
+			End       string `json:"end"`
+			EndDate   string `json:"end_date"`
+			Timezone  string `json:"timezone"`
+		} `json:"range"`
+	} `json:"data"`
+
+	raw rawJSON
+}
+
+// /leaders and /users/current/leaderboards/:board
+type Leader struct {
+	Rank         int `json:"rank"`
+	RunningTotal struct {
+		TotalSeconds              float64    `json:"total_seconds"`
+		HumanReadableTotal        string     `json:"human_readable_total"`
+		DailyAverage              float64    `json:"daily_average"`
+		HumanReadableDailyAverage string     `json:"human_readable_daily_average"`
+		Languages                 []StatItem `json:"languages"` // by time, with Name and TotalSeconds only
+	} `json:"running_total"`
+	User LeaderUser `json:"user"`
+}
+
+type LeaderUser struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	FullName      string `json:"full_name"`
+	Location      string `json:"location"`
+	Website       string `json:"website"`
+	IsHireable    bool   `json:"is_hireable"`
+	Photo         string `json:"photo"`
+	IsEmailPublic bool   `json:"is_email_public"`
+	Email         string `json:"email"`
+}
+
+type LeadersResponse struct {
+	Data       []Leader `json:"data"`
+	Page       int      `json:"page"`
+	TotalPages int      `json:"total_pages"`
+	Language   string   `json:"language"` // the language filter, if any
+	ModifiedAt string   `json:"modified_at"`
+	WritesOnly bool     `json:"writes_only"`
+	Timeout    int      `json:"timeout"`
+	// CurrentUser is where the user of the API key ranks, nil when they aren't on the board.
+	CurrentUser *struct {
+		Rank int        `json:"rank"`
+		Page int        `json:"page"`
+		User LeaderUser `json:"user"`
+	} `json:"current_user"`
+	Range struct {
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		Name      string `json:"name"` // e.g. last_7_days
+		Text      string `json:"text"` // e.g. Last 7 Days
+	} `json:"range"`
+
+	raw rawJSON
+}
+
+// /users/current/orgs, WakaTime only
+type Org struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Timezone   string `json:"timezone"`
+	Timeout    int    `json:"timeout"`
+	WritesOnly bool   `json:"writes_only"`
+	CreatedAt  string `json:"created_at"`
+	ModifiedAt string `json:"modified_at"`
+}
+
+type OrgsResponse struct {
+	Data       []Org `json:"data"`
+	Total      int   `json:"total"`
+	TotalPages int   `json:"total_pages"`
+
+	raw rawJSON
+}
+
+// /users/current/orgs/:org/dashboards
+type Dashboard struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	FullName            string `json:"full_name"` // with the org's name
+	MembersCount        int    `json:"members_count"`
+	Timezone            string `json:"timezone"`
+	IsManualTimeHidden  bool   `json:"is_manual_time_hidden"`
+	CanCurrentUserView  bool   `json:"can_current_user_view"`
+	CanCurrentUserAdmin bool   `json:"can_current_user_admin"`
+	CreatedAt           string `json:"created_at"`
+	ModifiedAt          string `json:"modified_at"`
+}
+
+type DashboardsResponse struct {
+	Data       []Dashboard `json:"data"`
+	Total      int         `json:"total"`
+	TotalPages int         `json:"total_pages"`
+
+	raw rawJSON
+}
+
+// /users/current/orgs/:org/dashboards/:dashboard/members
+type DashboardMember struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	FullName   string `json:"full_name"`
+	Email      string `json:"email"`
+	Photo      string `json:"photo"`
+	IsViewOnly bool   `json:"is_view_only"`
+}
+
+type DashboardMembersResponse struct {
+	Data       []DashboardMember `json:"data"`
+	Total      int               `json:"total"`
+	TotalPages int               `json:"total_pages"`
+
+	raw rawJSON
 }
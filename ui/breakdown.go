@@ -59,13 +59,16 @@ func dailyRowsStr(dailyData []types.DayData, cols dailyColumns, maxSecs float64)
 	output := make([]string, 0, len(dailyData))
 
 	for _, day := range dailyData {
-		if day.GrandTotal.TotalSeconds < 60 {
+		if day.GrandTotal.TotalSeconds < Opts.MinSeconds {
 			continue
 		}
 
 		date := fmt.Sprintf("%-*s", cols.date, formatDailyDate(day.Range.Start))
 
-		barLength := int((day.GrandTotal.TotalSeconds / maxSecs) * maxTableBarWidth)
+		barLength := 0 // with --min 0, a range without activity has a maxSecs of 0
+		if maxSecs > 0 {
+			barLength = int((day.GrandTotal.TotalSeconds / maxSecs) * maxTableBarWidth)
+		}
 		if barLength < 1 && day.GrandTotal.TotalSeconds > 0 {
 			barLength = 1
 		}
@@ -114,7 +117,7 @@ func calculateDailyColumnWidths(dailyData []types.DayData) dailyColumns {
 	maxSecs := findMaxDailySeconds(dailyData)
 
 	for _, day := range dailyData {
-		if day.GrandTotal.TotalSeconds < 60 {
+		if day.GrandTotal.TotalSeconds < Opts.MinSeconds {
 			continue
 		}
 
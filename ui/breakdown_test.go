@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestDailyRowsStrWithoutActivity(t *testing.T) {
+	defer func(min float64) { Opts.MinSeconds = min }(Opts.MinSeconds)
+	Opts.MinSeconds = 0 // --min 0 keeps the days without activity
+
+	days := []types.DayData{{}, {}}
+	days[0].Range.Start = "2026-10-18T00:00:00Z"
+	days[1].Range.Start = "2026-10-19T00:00:00Z"
+	cols := dailyColumns{date: 12, time: 20, lang: 8, project: 8}
+	rows := dailyRowsStr(days, cols, findMaxDailySeconds(days))
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import "strings"
+
+// canvas is a drawing surface for a Screen (an SVG document or a PNG image).
+// Coordinates are in pixels, colors are hex strings from a Theme.
+type canvas interface {
+	rect(x, y, w, h float64, color string)
+	// text draws s starting at the top-left corner of the cell at (x, y),
+	// one cell per rune, two for wide runes (see runeWidth).
+	text(x, y float64, s string, color string, bold bool)
+}
+
+// metrics is the fixed cell size of a canvas, so output doesn't depend on the installed fonts.
+type metrics struct {
+	cellW   float64
+	cellH   float64
+	padding float64
+}
+
+func (m metrics) size(s *Screen) (float64, float64) {
+	return 2*m.padding + float64(s.cols)*m.cellW, 2*m.padding + float64(len(s.lines))*m.cellH
+}
+
+// drawScreen draws s on cv. Box drawing, bar and heatmap characters are drawn
+// as shapes so they line up exactly, everything else goes through cv.text.
+func drawScreen(cv canvas, s *Screen, t Theme, m metrics) {
+	w, h := m.size(s)
+	cv.rect(0, 0, w, h, t.Background)
+
+	for row, line := range s.lines {
+		y := m.padding + float64(row)*m.cellH
+		runStart := -1
+		flush := func(end int) {
+			if runStart < 0 {
+				return
+			}
+			var text strings.Builder
+			for _, c := range line[runStart:end] {
+				if c.r != 0 { // not the second half of a wide rune
+					text.WriteRune(c.r)
+					text.WriteString(c.marks)
+				}
+			}
+			st := line[runStart].style
+			cv.text(m.padding+float64(runStart)*m.cellW, y, text.String(), t.color(st), st.bold)
+			runStart = -1
+		}
+
+		for col := 0; col < len(line); col++ {
+			c := line[col]
+			x := m.padding + float64(col)*m.cellW
+			if c.r == ' ' {
+				flush(col)
+				continue
+			}
+			// runs of bars and lines are drawn as one shape
+			n := 1
+			if c.r == '─' || c.r == '▆' || c.r == '█' {
+				for col+n < len(line) && line[col+n] == c {
+					n++
+				}
+			}
+			if drawShape(cv, c.r, x, y, float64(n)*m.cellW, m, t.color(c.style)) {
+				flush(col)
+				col += n - 1
+				continue
+			}
+			if runStart >= 0 && line[runStart].style != c.style {
+				flush(col)
+			}
+			if runStart < 0 {
+				runStart = col
+			}
+		}
+		flush(len(line))
+	}
+}
+
+// drawShape draws the characters the card views are built from, reporting false for anything else.
+// width is the width of the run for repeated '─', '▆' and '█', otherwise one cell.
+func drawShape(cv canvas, r rune, x, y, width float64, m metrics, color string) bool {
+	cw, ch := m.cellW, m.cellH
+	stroke := max(1, ch/16)
+	midX, midY := x+cw/2-stroke/2, y+ch/2-stroke/2
+	hLine := func(x0, x1 float64) { cv.rect(x0, midY, x1-x0, stroke, color) }
+	vLine := func(y0, y1 float64) { cv.rect(midX, y0, stroke, y1-y0, color) }
+
+	switch r {
+	case '─':
+		hLine(x, x+width)
+	case '│':
+		vLine(y, y+ch)
+	case '┼':
+		hLine(x, x+cw)
+		vLine(y, y+ch)
+	case '╭':
+		hLine(midX, x+cw)
+		vLine(midY, y+ch)
+	case '╮':
+		hLine(x, midX+stroke)
+		vLine(midY, y+ch)
+	case '╰':
+		hLine(midX, x+cw)
+		vLine(y, midY+stroke)
+	case '╯':
+		hLine(x, midX+stroke)
+		vLine(y, midY+stroke)
+	case '▆': // bars: lower three quarters block
+		top := y + ch*0.25
+		cv.rect(x, top, width, y+ch-top, color)
+	case '█': // full block: the color blocks of --fetch
+		cv.rect(x, y, width, ch, color)
+	case '■': // heatmap square
+		side := cw * 0.8
+		cv.rect(x+(cw-side)/2, y+(ch-side)/2, side, side, color)
+	default:
+		return false
+	}
+	return true
+}
@@ -7,23 +7,29 @@ type Colors struct {
 	BoldBlue string
 	Blue     string
 	Green    string
+	Magenta  string
+	Cyan     string
 	Gray     string
 	Bold     string
 	Reset    string
 }
 
-var Clr Colors = Colors{
+var defaultColors = Colors{
 	MidGray:  "\x1b[38;2;128;128;128m",
 	Red:      "\x1b[31m",
 	Yellow:   "\x1b[33m",
 	BoldBlue: "\x1b[1;34m",
 	Blue:     "\x1b[34m",
 	Green:    "\x1b[32m",
+	Magenta:  "\x1b[35m",
+	Cyan:     "\x1b[36m",
 	Gray:     "\x1b[90m",
 	Bold:     "\x1b[1m",
 	Reset:    "\x1b[0m",
 }
 
+var Clr Colors = defaultColors
+
 func DisableColors() {
 	Clr = Colors{
 		MidGray:  "",
@@ -32,6 +38,8 @@ func DisableColors() {
 		BoldBlue: "",
 		Blue:     "",
 		Green:    "",
+		Magenta:  "",
+		Cyan:     "",
 		Gray:     "",
 		Bold:     "",
 		Reset:    "",
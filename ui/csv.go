@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// dimension is one named item list of a Breakdown, e.g. ("language", Languages).
+type dimension struct {
+	name  string
+	title string
+	items []Item
+}
+
+// dimensions lists the breakdown's item lists in display order.
+func (b Breakdown) dimensions() []dimension {
+	return []dimension{
+		{"language", "Languages", b.Languages},
+		{"project", "Projects", b.Projects},
+		{"editor", "Editors", b.Editors},
+		{"os", "Operating Systems", b.OperatingSystems},
+		{"category", "Categories", b.Categories},
+		{"machine", "Machines", b.Machines},
+		{"branch", "Branches", b.Branches},
+		{"dependency", "Dependencies", b.Dependencies},
+		{"entity", "Entities", b.Entities},
+	}
+}
+
+// WriteDailyCSV writes one row per day: totals and the top item of each dimension.
+// comma is ',' for CSV or '\t' for TSV.
+func WriteDailyCSV(w io.Writer, v *View, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write([]string{"date", "total_seconds", "top_language", "top_project", "top_editor", "top_os", "top_category", "top_machine"})
+	for _, d := range v.Daily {
+		cw.Write([]string{
+			d.Date,
+			secondsStr(d.Seconds),
+			d.TopLanguage,
+			d.TopProject,
+			topName(d.Editors, false),
+			topName(d.OperatingSystems, false),
+			topName(d.Categories, false),
+			topName(d.Machines, false),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteItemsCSV writes one row per item of every dimension, aggregated over the range.
+func WriteItemsCSV(w io.Writer, v *View, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write([]string{"dimension", "name", "total_seconds", "percent"})
+	for _, dim := range v.dimensions() {
+		for _, item := range dim.items {
+			cw.Write([]string{dim.name, item.Name, secondsStr(item.Seconds), fmt.Sprintf("%.2f", item.Percent)})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteLongCSV writes the tidy format: one row per day, dimension and item.
+func WriteLongCSV(w io.Writer, v *View, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write([]string{"date", "dimension", "name", "seconds"})
+	for _, d := range v.Daily {
+		for _, dim := range d.dimensions() {
+			for _, item := range dim.items {
+				cw.Write([]string{d.Date, dim.name, item.Name, secondsStr(item.Seconds)})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func secondsStr(seconds float64) string {
+	return fmt.Sprintf("%.0f", seconds)
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func csvSummary() *types.SummaryResponse {
+	days := daysOf(5400, 0, 1800)
+	days[0].Languages = statItems("Go", 3600, "Markdown", 1500)
+	days[0].Projects = statItems("wakafetch", 5400)
+	days[0].Editors = statItems("Neovim", 5400)
+	days[2].Languages = statItems("Go, templates", 1800)
+	days[2].Projects = statItems("Unknown Project", 600, "dotfiles", 1200)
+	return &types.SummaryResponse{Data: days, Start: days[0].Range.Start, End: days[2].Range.End}
+}
+
+func TestCSVWriters(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(io.Writer, *View, rune) error
+		comma rune
+		want  string
+	}{
+		{"daily", WriteDailyCSV, ',', `date,total_seconds,top_language,top_project,top_editor,top_os,top_category,top_machine
+2026-10-01,5400,Go,wakafetch,Neovim,,,
+2026-10-02,0,,,,,,
+2026-10-03,1800,"Go, templates",dotfiles,,,,
+`},
+		{"items", WriteItemsCSV, ',', `dimension,name,total_seconds,percent
+language,Go,3600,52.17
+language,"Go, templates",1800,26.09
+language,Markdown,1500,21.74
+project,wakafetch,5400,75.00
+project,dotfiles,1200,16.67
+project,Unknown Project,600,8.33
+editor,Neovim,5400,100.00
+`},
+		{"long, tsv", WriteLongCSV, '\t', "date\tdimension\tname\tseconds\n" +
+			"2026-10-01\tlanguage\tGo\t3600\n" +
+			"2026-10-01\tlanguage\tMarkdown\t1500\n" +
+			"2026-10-01\tproject\twakafetch\t5400\n" +
+			"2026-10-01\teditor\tNeovim\t5400\n" +
+			"2026-10-03\tlanguage\tGo, templates\t1800\n" +
+			"2026-10-03\tproject\tdotfiles\t1200\n" +
+			"2026-10-03\tproject\tUnknown Project\t600\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := tt.write(&sb, NewSummaryView(csvSummary(), "Last 3 days"), tt.comma); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+}
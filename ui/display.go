@@ -21,16 +21,28 @@ type DisplayPayload struct {
 	OperatingSystems []types.StatItem
 	Categories       []types.StatItem
 	Machines         []types.StatItem
+	Branches         []types.StatItem
+	Dependencies     []types.StatItem
 	Entities         []types.StatItem
 	Full             bool
+	FirstCard        int // index of the selected card the cards start at (the TUI's c key)
 }
 
 func DisplayStats(data *types.StatsResponse, full bool, rangeStr string) {
-	if data == nil || (data.Data.TotalSeconds == 0 && len(data.Data.Languages) == 0 && len(data.Data.Projects) == 0) {
+	if !HasStatsData(data) {
 		Warnln("No data available for the selected period: '%s'", rangeStr)
 		return
 	}
+	render(NewStatsPayload(data, full, rangeStr))
+}
+
+// HasStatsData reports whether a /stats response has anything to display.
+func HasStatsData(data *types.StatsResponse) bool {
+	return data != nil && (data.Data.TotalSeconds != 0 || len(data.Data.Languages) != 0 || len(data.Data.Projects) != 0)
+}
 
+// NewStatsPayload builds the stats fields and cards for a /stats response.
+func NewStatsPayload(data *types.StatsResponse, full bool, rangeStr string) *DisplayPayload {
 	stats := data.Data
 	var heading string
 	if rangeStr == "all_time" {
@@ -49,26 +61,32 @@ func DisplayStats(data *types.StatsResponse, full bool, rangeStr string) {
 	numLangs := fmt.Sprintf("%d", len(stats.Languages))
 	numProjects := fmt.Sprintf("%d", len(stats.Projects))
 
-	var statsMap []Field
-	statsMap = []Field{
-		{"Total Time", totalTime},
+	available := map[string]Field{
+		"total":     {"Total Time", totalTime},
+		"language":  {"Top Language", topItemName(stats.Languages, false)},
+		"project":   {"Top Project", topProject},
+		"editor":    {"Top Editor", topEditor},
+		"os":        {"Top OS", topOS},
+		"category":  {"Top Category", topItemName(stats.Categories, false)},
+		"machine":   {"Top Machine", topItemName(stats.Machines, false)},
+		"languages": {"Languages", numLangs},
+		"projects":  {"Projects", numProjects},
 	}
 
 	if stats.DaysIncludingHolidays > 1 {
-		statsMap = append(statsMap,
-			Field{"Daily Avg", dailyAvg},
-		)
+		available["avg"] = Field{"Daily Avg", dailyAvg}
+		// WakaTime only: Wakapi has neither holidays nor a best day in /stats
+		if active := stats.DaysMinusHolidays; active != nil && *active > 0 {
+			available["active"] = Field{"Active Days", fmt.Sprintf("%d/%d days", *active, stats.DaysIncludingHolidays)}
+		}
+		if best := stats.BestDay; best != nil && best.TotalSeconds > 0 {
+			available["best"] = Field{"Best Day", fmt.Sprintf("%s (%s)", formatBestDay(best.Date), timeFmt(best.TotalSeconds))}
+		}
 	}
 
-	statsMap = append(statsMap,
-		Field{"Top Project", topProject},
-		Field{"Top Editor", topEditor},
-		Field{"Top OS", topOS},
-		Field{"Languages", numLangs},
-		Field{"Projects", numProjects},
-	)
+	statsMap := selectFields(available)
 
-	payload := DisplayPayload{
+	return &DisplayPayload{
 		Heading:          heading,
 		Stats:            statsMap,
 		Languages:        stats.Languages,
@@ -77,10 +95,11 @@ func DisplayStats(data *types.StatsResponse, full bool, rangeStr string) {
 		OperatingSystems: stats.OperatingSystems,
 		Categories:       stats.Categories,
 		Machines:         stats.Machines,
+		Branches:         stats.Branches,
+		Dependencies:     stats.Dependencies,
 		Entities:         nil, // stats response doesn't have entities
 		Full:             full,
 	}
-	render(&payload)
 }
 
 type job struct {
@@ -93,87 +112,56 @@ func DisplaySummary(data *types.SummaryResponse, full bool, rangeStr string) {
 		Warnln("No data available for the selected period: '%s'", rangeStr)
 		return
 	}
+	render(NewSummaryPayload(data, full, rangeStr))
+}
 
-	languages := make(map[string]float64)
-
-	// Only process additional data if full mode is on
-	projects, editors, operatingSystems, categories, machines, entities := make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64)
-
-	aggregateJobs := []job{
-		{languages, func(day types.DayData) []types.StatItem { return day.Languages }},
-		{projects, func(day types.DayData) []types.StatItem { return day.Projects }},
-		{editors, func(day types.DayData) []types.StatItem { return day.Editors }},
-		{operatingSystems, func(day types.DayData) []types.StatItem { return day.OperatingSystems }},
-		{categories, func(day types.DayData) []types.StatItem { return day.Categories }},
-		{machines, func(day types.DayData) []types.StatItem { return day.Machines }},
-		{entities, func(day types.DayData) []types.StatItem { return day.Entities }},
-	}
-
-	processJobs(data.Data, aggregateJobs)
-
-	// Find busiest day
-	busiestDay := ""
-	busiestDaySeconds := 0.0
-	for _, dayData := range data.Data {
-		if dayData.GrandTotal.TotalSeconds > busiestDaySeconds {
-			busiestDaySeconds = dayData.GrandTotal.TotalSeconds
-			busiestDay = dayData.Range.Date
-		}
-	}
-
-	aggregatedLangs := mapToSortedStatItems(languages)
-	aggregatedProjs := mapToSortedStatItems(projects)
-	aggregatedEditors := mapToSortedStatItems(editors)
-	aggregatedOS := mapToSortedStatItems(operatingSystems)
-	aggregatedCategories := mapToSortedStatItems(categories)
-	aggregatedMachines := mapToSortedStatItems(machines)
+// NewSummaryPayload aggregates a /summaries response into stats fields and cards.
+func NewSummaryPayload(data *types.SummaryResponse, full bool, rangeStr string) *DisplayPayload {
+	payload := aggregateDays(data.Data)
+	busiestDay, busiestDaySeconds := findBusiestDay(data.Data)
 
 	heading := formatRangeHeading(rangeStr) + " (" + formatDateRange(data.Start, data.End) + ")"
 	totalTime := timeFmt(data.CumulativeTotal.Seconds)
 	dailyAvg := timeFmt(data.DailyAverage.Seconds)
 	activeDays := fmt.Sprintf("%d/%d days", data.DailyAverage.DaysMinusHolidays, data.DailyAverage.DaysIncludingHolidays)
 
-	topProject := topItemName(aggregatedProjs, true)
-	topEditor := topItemName(aggregatedEditors, false)
-	topOS := topItemName(aggregatedOS, false)
-	// topCategory := topItemName(aggregatedCategories, false)
-
-	numLangs := fmt.Sprintf("%d", len(aggregatedLangs))
-	numProjects := fmt.Sprintf("%d", len(aggregatedProjs))
-
-	var statsMap []Field
-	statsMap = []Field{
-		{"Total Time", totalTime},
+	topProject := topItemName(payload.Projects, true)
+	topEditor := topItemName(payload.Editors, false)
+	topOS := topItemName(payload.OperatingSystems, false)
+
+	numLangs := fmt.Sprintf("%d", len(payload.Languages))
+	numProjects := fmt.Sprintf("%d", len(payload.Projects))
+
+	available := map[string]Field{
+		"total":     {"Total Time", totalTime},
+		"language":  {"Top Language", topItemName(payload.Languages, false)},
+		"project":   {"Top Project", topProject},
+		"editor":    {"Top Editor", topEditor},
+		"os":        {"Top OS", topOS},
+		"category":  {"Top Category", topItemName(payload.Categories, false)},
+		"machine":   {"Top Machine", topItemName(payload.Machines, false)},
+		"languages": {"Languages", numLangs},
+		"projects":  {"Projects", numProjects},
 	}
 
 	if len(data.Data) > 1 {
-		statsMap = append(statsMap,
-			Field{"Daily Avg", dailyAvg},
-			Field{"Active Days", activeDays},
-			Field{"Best Day", fmt.Sprintf("%s (%s)", formatBestDay(busiestDay), timeFmt(busiestDaySeconds))},
-		)
-	}
-
-	statsMap = append(statsMap,
-		Field{"Top Project", topProject},
-		Field{"Top Editor", topEditor},
-		Field{"Top OS", topOS},
-		Field{"Languages", numLangs},
-		Field{"Projects", numProjects},
-	)
-
-	payload := &DisplayPayload{
-		Heading:          heading,
-		Stats:            statsMap,
-		Languages:        aggregatedLangs,
-		Editors:          aggregatedEditors,
-		Projects:         aggregatedProjs,
-		OperatingSystems: aggregatedOS,
-		Full:             full,
-		Categories:       aggregatedCategories,
-		Machines:         aggregatedMachines,
+		available["avg"] = Field{"Daily Avg", dailyAvg}
+		available["active"] = Field{"Active Days", activeDays}
+		available["best"] = Field{"Best Day", fmt.Sprintf("%s (%s)", formatBestDay(busiestDay), timeFmt(busiestDaySeconds))}
+		current, longest := streaks(data.Data)
+		streak := fmt.Sprintf("%d days", current)
+		if longest > current {
+			streak += fmt.Sprintf(" (longest %d)", longest)
+		}
+		available["streak"] = Field{"Streak", streak}
 	}
-	render(payload)
+
+	statsMap := selectFields(available)
+
+	payload.Heading = heading
+	payload.Stats = statsMap
+	payload.Full = full
+	return payload
 }
 
 func DisplayBreakdown(data []types.DayData, heading string) {
@@ -208,6 +196,51 @@ func DisplayHeatmap(data []types.DayData, heading string) {
 	printStrs(cardHeatmap)
 }
 
+// aggregateDays sums the per-day items of a /summaries response over the whole range.
+func aggregateDays(days []types.DayData) *DisplayPayload {
+	languages, projects, editors, operatingSystems, categories := make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64)
+	machines, entities, branches, dependencies := make(map[string]float64), make(map[string]float64), make(map[string]float64), make(map[string]float64)
+
+	aggregateJobs := []job{
+		{languages, func(day types.DayData) []types.StatItem { return day.Languages }},
+		{projects, func(day types.DayData) []types.StatItem { return day.Projects }},
+		{editors, func(day types.DayData) []types.StatItem { return day.Editors }},
+		{operatingSystems, func(day types.DayData) []types.StatItem { return day.OperatingSystems }},
+		{categories, func(day types.DayData) []types.StatItem { return day.Categories }},
+		{machines, func(day types.DayData) []types.StatItem { return day.Machines }},
+		{entities, func(day types.DayData) []types.StatItem { return day.Entities }},
+		{branches, func(day types.DayData) []types.StatItem { return day.Branches }},
+		{dependencies, func(day types.DayData) []types.StatItem { return day.Dependencies }},
+	}
+
+	processJobs(days, aggregateJobs)
+
+	return &DisplayPayload{
+		Languages:        mapToSortedStatItems(languages),
+		Projects:         mapToSortedStatItems(projects),
+		Editors:          mapToSortedStatItems(editors),
+		OperatingSystems: mapToSortedStatItems(operatingSystems),
+		Categories:       mapToSortedStatItems(categories),
+		Machines:         mapToSortedStatItems(machines),
+		Entities:         mapToSortedStatItems(entities),
+		Branches:         mapToSortedStatItems(branches),
+		Dependencies:     mapToSortedStatItems(dependencies),
+	}
+}
+
+// findBusiestDay returns the date and total of the day with the most activity.
+func findBusiestDay(days []types.DayData) (string, float64) {
+	date := ""
+	seconds := 0.0
+	for _, dayData := range days {
+		if dayData.GrandTotal.TotalSeconds > seconds {
+			seconds = dayData.GrandTotal.TotalSeconds
+			date = dayData.Range.Date
+		}
+	}
+	return date, seconds
+}
+
 func processJobs(data []types.DayData, jobs []job) {
 	for _, dayData := range data {
 		for _, j := range jobs {
@@ -225,7 +258,31 @@ func mapToSortedStatItems(m map[string]float64) []types.StatItem {
 		items = append(items, types.StatItem{Name: name, TotalSeconds: seconds})
 	}
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].TotalSeconds > items[j].TotalSeconds
+		if items[i].TotalSeconds != items[j].TotalSeconds {
+			return items[i].TotalSeconds > items[j].TotalSeconds
+		}
+		return items[i].Name < items[j].Name // map order is random
 	})
 	return items
 }
+
+// streaks returns the current streak (consecutive active days ending at the last day,
+// or the day before it if nothing was logged yet) and the longest streak in the range.
+func streaks(days []types.DayData) (current, longest int) {
+	run := 0
+	for _, day := range days {
+		if day.GrandTotal.TotalSeconds > 0 {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	current = run
+	if current == 0 && len(days) > 1 {
+		for i := len(days) - 2; i >= 0 && days[i].GrandTotal.TotalSeconds > 0; i-- {
+			current++
+		}
+	}
+	return current, longest
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// daysOf builds summary days from Oct 1 2026 on, one per total.
+func daysOf(seconds ...float64) []types.DayData {
+	days := make([]types.DayData, len(seconds))
+	for i, secs := range seconds {
+		date := fmt.Sprintf("2026-10-%02d", i+1)
+		days[i].Range.Date = date
+		days[i].Range.Start = date + "T00:00:00Z"
+		days[i].Range.End = date + "T23:59:59Z"
+		days[i].GrandTotal.TotalSeconds = secs
+	}
+	return days
+}
+
+func TestStreaks(t *testing.T) {
+	tests := []struct {
+		name             string
+		days             []types.DayData
+		current, longest int
+	}{
+		{"empty", nil, 0, 0},
+		{"no activity", daysOf(0, 0, 0), 0, 0},
+		{"every day", daysOf(60, 60, 60), 3, 3},
+		{"up to the last day", daysOf(60, 0, 60, 60), 2, 2},
+		{"nothing logged yet today", daysOf(60, 60, 0), 2, 2},
+		{"broken yesterday", daysOf(60, 60, 0, 0), 0, 2},
+		{"longest earlier", daysOf(60, 60, 60, 0, 60), 1, 3},
+		{"one inactive day", daysOf(0), 0, 0},
+		{"one active day", daysOf(60), 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := streaks(tt.days)
+			if current != tt.current || longest != tt.longest {
+				t.Errorf("streaks() = %d, %d, want %d, %d", current, longest, tt.current, tt.longest)
+			}
+		})
+	}
+}
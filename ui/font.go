@@ -0,0 +1,135 @@
+package ui
+
+import "strings"
+
+// A 5x9 bitmap font for printable ASCII, used by the PNG exporter.
+// Rows are space separated, '#' is a set pixel. Rows 0-6 are the body
+// (baseline at row 6), rows 7-8 the descenders; missing rows are blank.
+const (
+	glyphW = 5
+	glyphH = 9
+)
+
+var glyphSource = map[rune]string{
+	' ':  "",
+	'!':  "..#.. ..#.. ..#.. ..#.. ..#.. ..... ..#..",
+	'"':  ".#.#. .#.#. .#.#.",
+	'#':  ".#.#. .#.#. ##### .#.#. ##### .#.#. .#.#.",
+	'$':  "..#.. .#### #.#.. .###. ..#.# ####. ..#..",
+	'%':  "##... ##..# ...#. ..#.. .#... #..## ...##",
+	'&':  ".##.. #..#. #.#.. .#... #.#.# #..#. .##.#",
+	'\'': "..#.. ..#.. .#...",
+	'(':  "...#. ..#.. .#... .#... .#... ..#.. ...#.",
+	')':  ".#... ..#.. ...#. ...#. ...#. ..#.. .#...",
+	'*':  "..... ..#.. #.#.# .###. #.#.# ..#.. .....",
+	'+':  "..... ..#.. ..#.. ##### ..#.. ..#.. .....",
+	',':  "..... ..... ..... ..... ..... .##.. ..#.. .#...",
+	'-':  "..... ..... ..... ##### ..... ..... .....",
+	'.':  "..... ..... ..... ..... ..... .##.. .##..",
+	'/':  "..... ....# ...#. ..#.. .#... #.... .....",
+	'0':  ".###. #...# #..## #.#.# ##..# #...# .###.",
+	'1':  "..#.. .##.. ..#.. ..#.. ..#.. ..#.. .###.",
+	'2':  ".###. #...# ....# ...#. ..#.. .#... #####",
+	'3':  "##### ...#. ..#.. ...#. ....# #...# .###.",
+	'4':  "...#. ..##. .#.#. #..#. ##### ...#. ...#.",
+	'5':  "##### #.... ####. ....# ....# #...# .###.",
+	'6':  "..##. .#... #.... ####. #...# #...# .###.",
+	'7':  "##### ....# ...#. ..#.. .#... .#... .#...",
+	'8':  ".###. #...# #...# .###. #...# #...# .###.",
+	'9':  ".###. #...# #...# .#### ....# ...#. .##..",
+	':':  "..... .##.. .##.. ..... .##.. .##.. .....",
+	';':  "..... .##.. .##.. ..... .##.. ..#.. .#...",
+	'<':  "...#. ..#.. .#... #.... .#... ..#.. ...#.",
+	'=':  "..... ..... ##### ..... ##### ..... .....",
+	'>':  ".#... ..#.. ...#. ....# ...#. ..#.. .#...",
+	'?':  ".###. #...# ....# ...#. ..#.. ..... ..#..",
+	'@':  ".###. #...# ....# .##.# #.#.# #.#.# .###.",
+	'A':  ".###. #...# #...# #...# ##### #...# #...#",
+	'B':  "####. #...# #...# ####. #...# #...# ####.",
+	'C':  ".###. #...# #.... #.... #.... #...# .###.",
+	'D':  "###.. #..#. #...# #...# #...# #..#. ###..",
+	'E':  "##### #.... #.... ####. #.... #.... #####",
+	'F':  "##### #.... #.... ####. #.... #.... #....",
+	'G':  ".###. #...# #.... #.### #...# #...# .####",
+	'H':  "#...# #...# #...# ##### #...# #...# #...#",
+	'I':  ".###. ..#.. ..#.. ..#.. ..#.. ..#.. .###.",
+	'J':  "..### ...#. ...#. ...#. ...#. #..#. .##..",
+	'K':  "#...# #..#. #.#.. ##... #.#.. #..#. #...#",
+	'L':  "#.... #.... #.... #.... #.... #.... #####",
+	'M':  "#...# ##.## #.#.# #.#.# #...# #...# #...#",
+	'N':  "#...# #...# ##..# #.#.# #..## #...# #...#",
+	'O':  ".###. #...# #...# #...# #...# #...# .###.",
+	'P':  "####. #...# #...# ####. #.... #.... #....",
+	'Q':  ".###. #...# #...# #...# #.#.# #..#. .##.#",
+	'R':  "####. #...# #...# ####. #.#.. #..#. #...#",
+	'S':  ".#### #.... #.... .###. ....# ....# ####.",
+	'T':  "##### ..#.. ..#.. ..#.. ..#.. ..#.. ..#..",
+	'U':  "#...# #...# #...# #...# #...# #...# .###.",
+	'V':  "#...# #...# #...# #...# #...# .#.#. ..#..",
+	'W':  "#...# #...# #...# #.#.# #.#.# #.#.# .#.#.",
+	'X':  "#...# #...# .#.#. ..#.. .#.#. #...# #...#",
+	'Y':  "#...# #...# .#.#. ..#.. ..#.. ..#.. ..#..",
+	'Z':  "##### ....# ...#. ..#.. .#... #.... #####",
+	'[':  ".###. .#... .#... .#... .#... .#... .###.",
+	'\\': "..... #.... .#... ..#.. ...#. ....# .....",
+	']':  ".###. ...#. ...#. ...#. ...#. ...#. .###.",
+	'^':  "..#.. .#.#. #...#",
+	'_':  "..... ..... ..... ..... ..... ..... ..... #####",
+	'`':  ".#... ..#..",
+	'a':  "..... ..... .###. ....# .#### #...# .####",
+	'b':  "#.... #.... #.##. ##..# #...# #...# ####.",
+	'c':  "..... ..... .###. #.... #.... #...# .###.",
+	'd':  "....# ....# .##.# #..## #...# #...# .####",
+	'e':  "..... ..... .###. #...# ##### #.... .###.",
+	'f':  "..##. .#..# .#... ###.. .#... .#... .#...",
+	'g':  "..... ..... .#### #...# #...# #...# .#### ....# .###.",
+	'h':  "#.... #.... #.##. ##..# #...# #...# #...#",
+	'i':  "..#.. ..... .##.. ..#.. ..#.. ..#.. .###.",
+	'j':  "...#. ..... ..##. ...#. ...#. ...#. ...#. #..#. .##..",
+	'k':  "#.... #.... #..#. #.#.. ##... #.#.. #..#.",
+	'l':  ".##.. ..#.. ..#.. ..#.. ..#.. ..#.. .###.",
+	'm':  "..... ..... ##.#. #.#.# #.#.# #.#.# #.#.#",
+	'n':  "..... ..... #.##. ##..# #...# #...# #...#",
+	'o':  "..... ..... .###. #...# #...# #...# .###.",
+	'p':  "..... ..... ####. #...# #...# #...# ####. #.... #....",
+	'q':  "..... ..... .#### #...# #...# #...# .#### ....# ....#",
+	'r':  "..... ..... #.##. ##..# #.... #.... #....",
+	's':  "..... ..... .#### #.... .###. ....# ####.",
+	't':  ".#... .#... ###.. .#... .#... .#..# ..##.",
+	'u':  "..... ..... #...# #...# #...# #..## .##.#",
+	'v':  "..... ..... #...# #...# #...# .#.#. ..#..",
+	'w':  "..... ..... #...# #...# #.#.# #.#.# .#.#.",
+	'x':  "..... ..... #...# .#.#. ..#.. .#.#. #...#",
+	'y':  "..... ..... #...# #...# #...# #...# .#### ....# .###.",
+	'z':  "..... ..... ##### ...#. ..#.. .#... #####",
+	'{':  "...#. ..#.. ..#.. .#... ..#.. ..#.. ...#.",
+	'|':  "..#.. ..#.. ..#.. ..#.. ..#.. ..#.. ..#..",
+	'}':  ".#... ..#.. ..#.. ...#. ..#.. ..#.. .#...",
+	'~':  "..... ..... .#... #.#.# ...#. ..... .....",
+}
+
+// glyph rows as bitmasks, bit 4 is the leftmost pixel
+var glyphs = parseGlyphs(glyphSource)
+
+// missingGlyph is drawn for runes the font doesn't have: a hollow box.
+var missingGlyph = parseGlyph("##### #...# #...# #...# #...# #...# #####")
+
+func parseGlyphs(src map[rune]string) map[rune][glyphH]uint8 {
+	parsed := make(map[rune][glyphH]uint8, len(src))
+	for r, rows := range src {
+		parsed[r] = parseGlyph(rows)
+	}
+	return parsed
+}
+
+func parseGlyph(rows string) [glyphH]uint8 {
+	var g [glyphH]uint8
+	for y, row := range strings.Fields(rows) {
+		for x, c := range row {
+			if c == '#' {
+				g[y] |= 1 << (glyphW - 1 - x)
+			}
+		}
+	}
+	return g
+}
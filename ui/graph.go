@@ -7,33 +7,68 @@ import (
 	"github.com/andatoshiki/wakafetch/wakafetch/types"
 )
 
-func graphStr(items []types.StatItem, limit int) ([]string, int) {
-	if len(items) == 0 {
-		return []string{}, 0
+const otherName = "Other"
+
+// collapseItems keeps the first limit items (0 for all) at or above minSeconds and folds
+// everything else into a trailing "Other" row, along with the data's own "Other" item
+// (WakaTime has one for languages and categories). items must be sorted by time, descending.
+func collapseItems(items []types.StatItem, limit int, minSeconds float64) []types.StatItem {
+	kept := make([]types.StatItem, 0, len(items))
+	other, hasOther := 0.0, false
+	for _, item := range items {
+		switch {
+		case item.Name == otherName:
+			other += item.TotalSeconds
+			hasOther = true
+		case item.TotalSeconds >= minSeconds && (limit <= 0 || len(kept) < limit):
+			kept = append(kept, item)
+		default:
+			other += item.TotalSeconds
+		}
 	}
-	count := len(items)
-	if limit > 0 && limit < count {
-		count = limit
+	if other > 0 || hasOther {
+		kept = append(kept, types.StatItem{Name: otherName, TotalSeconds: other})
 	}
-	visibleItems := items[:count]
+	return kept
+}
 
-	if len(visibleItems) == 0 || visibleItems[0].TotalSeconds == 0 {
-		return []string{}, 0
+func totalSeconds(items []types.StatItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.TotalSeconds
 	}
+	return total
+}
 
-	output := make([]string, 0, len(visibleItems))
+func percentStr(seconds, total float64) string {
+	if total <= 0 {
+		return fmt.Sprintf("%5.1f%%", 0.0)
+	}
+	return fmt.Sprintf("%5.1f%%", seconds/total*100)
+}
 
+func graphStr(items []types.StatItem, limit int) ([]string, int) {
+	if len(items) == 0 {
+		return []string{}, 0
+	}
+	return graphLines(collapseItems(items, limit, Opts.MinSeconds), totalSeconds(items))
+}
+
+// graphLines draws a bar per item, scaled to the largest one, with its share of total.
+func graphLines(visibleItems []types.StatItem, total float64) ([]string, int) {
 	maxNameLength := 0
 	maxSeconds := 0.0
 	for _, item := range visibleItems {
 		maxNameLength = max(maxNameLength, len(item.Name))
 		maxSeconds = max(maxSeconds, item.TotalSeconds)
 	}
+	if len(visibleItems) == 0 || maxSeconds == 0 {
+		return []string{}, 0
+	}
+
+	output := make([]string, 0, len(visibleItems))
 	for _, item := range visibleItems {
-		if item.TotalSeconds < 60 {
-			continue
-		}
-		barLength := int((item.TotalSeconds / visibleItems[0].TotalSeconds) * float64(barWidth))
+		barLength := int((item.TotalSeconds / maxSeconds) * float64(barWidth))
 		secondBarLength := barWidth - barLength
 		if barLength < 1 {
 			barLength = 1
@@ -49,9 +84,10 @@ func graphStr(items []types.StatItem, limit int) ([]string, int) {
 		line := label +
 			Clr.Green + bar + Clr.Reset +
 			Clr.Gray + secondBar + Clr.Reset + " " +
-			Clr.Green + timeFmtPad(item.TotalSeconds, maxSeconds) + Clr.Reset
+			Clr.Green + timeFmtPad(item.TotalSeconds, maxSeconds) + Clr.Reset + " " +
+			percentStr(item.TotalSeconds, total)
 		output = append(output, line)
 	}
-	graphWidth := maxNameLength + 1 + barWidth + 1 + len(timeFmtPad(maxSeconds, maxSeconds))
+	graphWidth := maxNameLength + 1 + barWidth + 1 + len(timeFmtPad(maxSeconds, maxSeconds)) + 1 + len(percentStr(total, total))
 	return output, graphWidth
 }
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func statItems(pairs ...any) []types.StatItem {
+	var out []types.StatItem
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, types.StatItem{Name: pairs[i].(string), TotalSeconds: float64(pairs[i+1].(int))})
+	}
+	return out
+}
+
+func TestCollapseItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []types.StatItem
+		limit int
+		min   float64
+		want  []types.StatItem
+	}{
+		{"no limit", statItems("Go", 300, "Python", 200, "Rust", 100), 0, 60, statItems("Go", 300, "Python", 200, "Rust", 100)},
+		{"no limit, folded", statItems("Go", 300, "Python", 200, "Rust", 30), 0, 60, statItems("Go", 300, "Python", 200, "Other", 30)},
+		{"no limit, all folded", statItems("Go", 30, "Rust", 20), 0, 60, statItems("Other", 50)},
+		{"limit 1", statItems("Go", 300, "Python", 200, "Rust", 100), 1, 60, statItems("Go", 300, "Other", 300)},
+		{"limit 1, one item", statItems("Go", 300), 1, 60, statItems("Go", 300)},
+		{"limit 1, one kept and folded", statItems("Go", 300, "Rust", 30), 1, 60, statItems("Go", 300, "Other", 30)},
+		{"limit N, under", statItems("Go", 300, "Python", 200), 3, 60, statItems("Go", 300, "Python", 200)},
+		{"limit N, exactly", statItems("Go", 300, "Python", 200, "Rust", 100), 3, 60, statItems("Go", 300, "Python", 200, "Rust", 100)},
+		{"limit N, over", statItems("Go", 300, "Python", 200, "Rust", 100, "C", 90), 3, 60, statItems("Go", 300, "Python", 200, "Rust", 100, "Other", 90)},
+		{"limit N, exactly and folded", statItems("Go", 300, "Python", 200, "Rust", 100, "C", 30), 3, 60, statItems("Go", 300, "Python", 200, "Rust", 100, "Other", 30)},
+		{"real Other, folded into", statItems("Go", 300, "Other", 200, "Rust", 30), 0, 60, statItems("Go", 300, "Other", 230)},
+		{"real Other, kept last", statItems("Other", 300, "Go", 200), 0, 60, statItems("Go", 200, "Other", 300)},
+		{"real Other, not counted in the limit", statItems("Go", 300, "Other", 200, "Rust", 100, "C", 90), 2, 60, statItems("Go", 300, "Rust", 100, "Other", 290)},
+		{"min 0", statItems("Go", 300, "Rust", 0), 0, 0, statItems("Go", 300, "Rust", 0)},
+		{"empty", nil, 2, 60, []types.StatItem{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collapseItems(tt.items, tt.limit, tt.min)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collapseItems(limit %d, min %v) = %v, want %v", tt.limit, tt.min, got, tt.want)
+			}
+			if tt.limit > 0 && len(got) > tt.limit+1 {
+				t.Errorf("collapseItems(limit %d) returned %d rows", tt.limit, len(got))
+			}
+		})
+	}
+}
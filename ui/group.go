@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// Group breaks one item of a dimension down by another card's items,
+// e.g. a project by its branches (--by branch).
+type Group struct {
+	Name         string           `json:"name"`
+	TotalSeconds float64          `json:"total_seconds"`
+	Items        []types.StatItem `json:"items"`
+}
+
+// NewGroup sums the items of a card ("branches", "dependencies", ...) over days into a group.
+func NewGroup(name, card string, days []types.DayData) Group {
+	items := cardItems(aggregateDays(days), card)
+	return Group{Name: name, TotalSeconds: totalSeconds(items), Items: items}
+}
+
+// heartbeatTimeout is how long a heartbeat counts at most when the next one is
+// further away: WakaTime's default keystroke timeout.
+const heartbeatTimeout = 15 * 60
+
+// DependencyGroups sums the time of heartbeats into their dependencies, grouped by language
+// (only language, when not empty). Like WakaTime's durations, a heartbeat counts until the
+// next one, up to heartbeatTimeout, and that time goes to each of its dependencies.
+// A group's TotalSeconds is the time in its language.
+func DependencyGroups(heartbeats []types.Heartbeat, language string) []Group {
+	sorted := make([]types.Heartbeat, len(heartbeats))
+	copy(sorted, heartbeats)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	languageSeconds := map[string]float64{}
+	dependencies := map[string]map[string]float64{}
+	for i, hb := range sorted {
+		if hb.Language == "" || (language != "" && !strings.EqualFold(hb.Language, language)) {
+			continue
+		}
+		seconds := 0.0
+		if i+1 < len(sorted) {
+			seconds = min(sorted[i+1].Time-hb.Time, heartbeatTimeout)
+		}
+		languageSeconds[hb.Language] += seconds
+		if dependencies[hb.Language] == nil {
+			dependencies[hb.Language] = map[string]float64{}
+		}
+		for _, dep := range hb.Dependencies {
+			dependencies[hb.Language][dep] += seconds
+		}
+	}
+
+	var groups []Group
+	for lang, seconds := range languageSeconds {
+		items := mapToSortedStatItems(dependencies[lang])
+		if len(items) == 0 || items[0].TotalSeconds == 0 {
+			continue
+		}
+		groups = append(groups, Group{Name: lang, TotalSeconds: seconds, Items: items})
+	}
+	// the groups come from a map: break ties on the name to keep the order stable
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].TotalSeconds != groups[j].TotalSeconds {
+			return groups[i].TotalSeconds > groups[j].TotalSeconds
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// DisplayGroups prints a card per group, listing its items like the --full cards.
+// groupTitle names what the groups are ("Projects"), card is the card of the items ("branches"),
+// for its --limit.
+func DisplayGroups(groups []Group, heading, groupTitle, card string) {
+	if len(groups) == 0 {
+		Warnln("No %s data available for the selected period: '%s'", cardTitles[card], heading)
+		return
+	}
+
+	total := 0.0
+	for _, g := range groups {
+		total += g.TotalSeconds
+	}
+	fields, fieldsWidth := fieldsStr(heading, []Field{
+		{"Total Time", timeFmt(total)},
+		{groupTitle, fmt.Sprintf("%d", len(groups))},
+	})
+	statsCard := CardConfig{Title: cardTitles[card], Lines: fields, Width: fieldsWidth}
+
+	shrink := getTerminalCols() < 96
+	var section CardSection
+	if shrink {
+		section.Left = append(section.Left, statsCard)
+	} else {
+		section.Right = append(section.Right, statsCard)
+	}
+	for i, g := range groups {
+		lines, width := graphStr(g.Items, cardLimit(CardSpec{Name: card}))
+		title := fmt.Sprintf("%s (%s)", g.Name, timeFmt(g.TotalSeconds))
+		c := CardConfig{Title: title, Lines: lines, Width: width}
+		if shrink || i%2 == 0 {
+			section.Left = append(section.Left, c)
+		} else {
+			section.Right = append(section.Right, c)
+		}
+	}
+	renderCardSection(section)
+}
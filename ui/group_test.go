@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestNewGroup(t *testing.T) {
+	days := daysOf(150, 70)
+	days[0].Branches = statItems("main", 100, "dev", 50)
+	days[1].Branches = statItems("feature", 50, "main", 20)
+	want := Group{Name: "wakafetch", TotalSeconds: 220, Items: statItems("main", 120, "dev", 50, "feature", 50)}
+	if got := NewGroup("wakafetch", "branches", days); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGroup() = %+v, want %+v", got, want)
+	}
+	if got := NewGroup("wakafetch", "dependencies", days); got.TotalSeconds != 0 || len(got.Items) != 0 {
+		t.Errorf("NewGroup() without dependencies = %+v", got)
+	}
+}
+
+func TestDependencyGroups(t *testing.T) {
+	heartbeat := func(time float64, language string, dependencies ...string) types.Heartbeat {
+		return types.Heartbeat{Time: day + time, Language: language, Dependencies: dependencies}
+	}
+	// out of order: DependencyGroups sorts them by time
+	heartbeats := []types.Heartbeat{
+		heartbeat(1860, "Python", "requests", "numpy"), // 1m
+		heartbeat(0, "Go", "fmt", "net/http"),          // 1m
+		heartbeat(60, "Go", "fmt"),                     // 30m later: capped to 15m
+		heartbeat(1920, "Python", "numpy", "attrs"),    // 1m
+		heartbeat(1980, ""),                            // no language: skipped
+		heartbeat(5000, "TypeScript", "react"),         // 2m
+		heartbeat(5120, "Rust", "serde"),               // last one, 0s: no Rust group
+	}
+	golang := Group{Name: "Go", TotalSeconds: 60 + heartbeatTimeout, Items: statItems("fmt", 60+heartbeatTimeout, "net/http", 60)}
+	python := Group{Name: "Python", TotalSeconds: 120, Items: statItems("numpy", 120, "attrs", 60, "requests", 60)}
+	typescript := Group{Name: "TypeScript", TotalSeconds: 120, Items: statItems("react", 120)}
+
+	tests := []struct {
+		language string
+		want     []Group
+	}{
+		{"", []Group{golang, python, typescript}}, // Python and TypeScript tie: by name
+		{"go", []Group{golang}},
+		{"Python", []Group{python}},
+		{"rust", nil},
+		{"zig", nil},
+	}
+	for _, tt := range tests {
+		// the groups and dependencies come from maps: the order must not depend on their iteration
+		for range 20 {
+			if got := DependencyGroups(heartbeats, tt.language); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DependencyGroups(%q) = %+v, want %+v", tt.language, got, tt.want)
+			}
+		}
+	}
+	if heartbeats[0].Language != "Python" {
+		t.Errorf("DependencyGroups modified its input: %v", heartbeats[0])
+	}
+}
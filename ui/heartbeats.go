@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// HeartbeatRow is a heartbeat with the names of the editor and machine that sent it.
+type HeartbeatRow struct {
+	types.Heartbeat
+	Editor  string `json:"editor"`
+	Machine string `json:"machine"`
+}
+
+// HeartbeatSource is an editor or a machine that sent heartbeats.
+type HeartbeatSource struct {
+	Name       string `json:"name"`
+	Heartbeats int    `json:"heartbeats"`
+	First      string `json:"first"` // HH:MM:SS
+	Last       string `json:"last"`
+}
+
+// HeartbeatGap is a break longer than heartbeatTimeout between two heartbeats.
+type HeartbeatGap struct {
+	Start   string  `json:"start"` // HH:MM:SS of the heartbeat before the break
+	End     string  `json:"end"`   // and after it
+	Seconds float64 `json:"seconds"`
+}
+
+// HeartbeatSummary sums up a day of heartbeats.
+type HeartbeatSummary struct {
+	Heartbeats    int               `json:"heartbeats"`
+	Writes        int               `json:"writes"`
+	First         string            `json:"first"` // HH:MM:SS, "" without heartbeats
+	Last          string            `json:"last"`
+	CodingSeconds float64           `json:"coding_seconds"` // timed like DependencyGroups
+	Gaps          []HeartbeatGap    `json:"gaps"`
+	Editors       []HeartbeatSource `json:"editors"`
+	Machines      []HeartbeatSource `json:"machines"`
+}
+
+// SortHeartbeats sorts rows by time, oldest first.
+func SortHeartbeats(rows []HeartbeatRow) {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Time < rows[j].Time })
+}
+
+// SummarizeHeartbeats counts the heartbeats of rows (sorted by time), times them and
+// lists the gaps between them and who sent them.
+func SummarizeHeartbeats(rows []HeartbeatRow) HeartbeatSummary {
+	s := HeartbeatSummary{Heartbeats: len(rows), Gaps: []HeartbeatGap{}}
+	if len(rows) == 0 {
+		return s
+	}
+	s.First, s.Last = heartbeatClock(rows[0].Time), heartbeatClock(rows[len(rows)-1].Time)
+
+	editors, machines := map[string]*HeartbeatSource{}, map[string]*HeartbeatSource{}
+	for i, row := range rows {
+		if row.IsWrite {
+			s.Writes++
+		}
+		if i+1 < len(rows) {
+			gap := rows[i+1].Time - row.Time
+			s.CodingSeconds += min(gap, heartbeatTimeout)
+			if gap > heartbeatTimeout {
+				s.Gaps = append(s.Gaps, HeartbeatGap{Start: heartbeatClock(row.Time), End: heartbeatClock(rows[i+1].Time), Seconds: gap})
+			}
+		}
+		countSource(editors, row.Editor, row.Time)
+		countSource(machines, row.Machine, row.Time)
+	}
+	s.Editors, s.Machines = sortedSources(editors), sortedSources(machines)
+	return s
+}
+
+func countSource(sources map[string]*HeartbeatSource, name string, t float64) {
+	if name == "" {
+		name = "Unknown"
+	}
+	src := sources[name]
+	if src == nil {
+		src = &HeartbeatSource{Name: name, First: heartbeatClock(t)}
+		sources[name] = src
+	}
+	src.Heartbeats++
+	src.Last = heartbeatClock(t)
+}
+
+func sortedSources(sources map[string]*HeartbeatSource) []HeartbeatSource {
+	list := make([]HeartbeatSource, 0, len(sources))
+	for _, src := range sources {
+		list = append(list, *src)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Heartbeats != list[j].Heartbeats {
+			return list[i].Heartbeats > list[j].Heartbeats
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// DisplayHeartbeats prints the summary of a day of heartbeats (date is YYYY-MM-DD) and
+// the table of rows (sorted by time).
+func DisplayHeartbeats(rows []HeartbeatRow, s HeartbeatSummary, date string) {
+	heading := formatBestDay(date)
+	if len(rows) == 0 {
+		Warnln("No heartbeats for the selected day: '%s'", heading)
+		return
+	}
+
+	gaps := "None"
+	if len(s.Gaps) > 0 {
+		longest := 0.0
+		for _, g := range s.Gaps {
+			longest = max(longest, g.Seconds)
+		}
+		gaps = fmt.Sprintf("%d (longest %s)", len(s.Gaps), timeFmt(longest))
+	}
+	fields, fieldsWidth := fieldsStr(heading, []Field{
+		{"Heartbeats", fmt.Sprintf("%d (%d writes)", s.Heartbeats, s.Writes)},
+		{"First, Last", s.First + " to " + s.Last},
+		{"Coding Time", timeFmt(s.CodingSeconds)},
+		{"Gaps", gaps},
+		{"Editors", fmt.Sprintf("%d", len(s.Editors))},
+		{"Machines", fmt.Sprintf("%d", len(s.Machines))},
+	})
+
+	var section CardSection
+	shrink := getTerminalCols() < 96
+	add := func(c CardConfig, right bool) {
+		if right && !shrink {
+			section.Right = append(section.Right, c)
+		} else {
+			section.Left = append(section.Left, c)
+		}
+	}
+	add(CardConfig{Title: "Heartbeats", Lines: fields, Width: fieldsWidth}, false)
+	sourceLines, sourceWidth := heartbeatSourcesStr(s)
+	add(CardConfig{Title: "Sent by", Lines: sourceLines, Width: sourceWidth}, true)
+	if len(s.Gaps) > 0 {
+		gapLines, gapWidth := heartbeatGapsStr(s.Gaps)
+		add(CardConfig{Title: "Gaps", Lines: gapLines, Width: gapWidth}, true)
+	}
+	renderCardSection(section)
+
+	table, tableWidth := heartbeatTableStr(rows)
+	card, _ := cardify(table, "Heartbeats of "+heading, tableWidth, 0)
+	printStrs(card)
+}
+
+// heartbeatSourcesStr lists the editors, then the machines, with their heartbeats and hours.
+func heartbeatSourcesStr(s HeartbeatSummary) ([]string, int) {
+	nameWidth := 0
+	for _, src := range slices.Concat(s.Editors, s.Machines) {
+		nameWidth = max(nameWidth, len(src.Name))
+	}
+	row := func(src HeartbeatSource) string {
+		return fmt.Sprintf("  %-*s  %5d  %s to %s", nameWidth, src.Name, src.Heartbeats, src.First, src.Last)
+	}
+	width := len("Machines")
+	for _, src := range slices.Concat(s.Editors, s.Machines) {
+		width = max(width, len(row(src)))
+	}
+
+	var lines []string
+	for _, group := range []struct {
+		title   string
+		sources []HeartbeatSource
+	}{{"Editors", s.Editors}, {"Machines", s.Machines}} {
+		lines = append(lines, Clr.Blue+fmt.Sprintf("%-*s", width, group.title)+Clr.Reset)
+		for _, src := range group.sources {
+			lines = append(lines, fmt.Sprintf("%-*s", width, row(src)))
+		}
+	}
+	return lines, width
+}
+
+func heartbeatGapsStr(gaps []HeartbeatGap) ([]string, int) {
+	lines := make([]string, 0, len(gaps))
+	width := 0
+	for _, g := range gaps {
+		line := fmt.Sprintf("%s to %s  %s", g.Start, g.End, timeFmt(g.Seconds))
+		lines = append(lines, line)
+		width = max(width, len(line))
+	}
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("%-*s", width, line)
+	}
+	return lines, width
+}
+
+// heartbeatColumns are the table's columns; the entity is shortened to fit the terminal.
+var heartbeatColumns = []string{"Time", "Entity", "Project", "Language", "Branch", "Editor", "Machine", "Write"}
+
+func heartbeatTableStr(rows []HeartbeatRow) ([]string, int) {
+	cells := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		write := ""
+		if row.IsWrite {
+			write = "yes"
+		}
+		cells = append(cells, []string{heartbeatClock(row.Time), row.Entity, row.Project, row.Language, row.Branch, row.Editor, row.Machine, write})
+	}
+	return table{columns: heartbeatColumns, rows: cells, fit: 1, shorten: shortenPath}.lines()
+}
+
+// WriteHeartbeatsCSV writes one row per heartbeat. comma is ',' for CSV or '\t' for TSV.
+func WriteHeartbeatsCSV(w io.Writer, rows []HeartbeatRow, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write([]string{"time", "entity", "type", "category", "project", "language", "branch", "editor", "machine", "is_write", "lines", "lineno", "dependencies"})
+	for _, row := range rows {
+		cw.Write([]string{
+			time.Unix(0, int64(row.Time*1e9)).Format(time.RFC3339),
+			row.Entity,
+			row.Type,
+			row.Category,
+			row.Project,
+			row.Language,
+			row.Branch,
+			row.Editor,
+			row.Machine,
+			strconv.FormatBool(row.IsWrite),
+			strconv.Itoa(row.Lines),
+			strconv.Itoa(row.LineNo),
+			strings.Join(row.Dependencies, ";"),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// heartbeatClock formats a heartbeat's unix time as local HH:MM:SS.
+func heartbeatClock(t float64) string {
+	return time.Unix(0, int64(t*1e9)).Format("15:04:05")
+}
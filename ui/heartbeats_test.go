@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// day is Oct 19 2026, 00:00 UTC; the tests print times in UTC.
+const day = 1792368000
+
+func heartbeatRow(offset float64, editor, machine string, write bool) HeartbeatRow {
+	return HeartbeatRow{Heartbeat: types.Heartbeat{Time: day + offset, IsWrite: write}, Editor: editor, Machine: machine}
+}
+
+func TestSummarizeHeartbeats(t *testing.T) {
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	empty := SummarizeHeartbeats(nil)
+	if want := (HeartbeatSummary{Gaps: []HeartbeatGap{}}); !reflect.DeepEqual(empty, want) {
+		t.Errorf("SummarizeHeartbeats(nil) = %+v, want %+v", empty, want)
+	}
+
+	rows := []HeartbeatRow{
+		heartbeatRow(9*3600, "vscode", "laptop", false),      // 09:00:00
+		heartbeatRow(9*3600+120, "vscode", "laptop", true),   // 09:02:00, 2m later
+		heartbeatRow(9*3600+1320, "neovim", "desktop", true), // 09:22:00, 20m later: a gap, timed as 15m
+		heartbeatRow(9*3600+1380, "", "desktop", false),      // 09:23:00, 1m later
+	}
+	got := SummarizeHeartbeats(rows)
+	want := HeartbeatSummary{
+		Heartbeats:    4,
+		Writes:        2,
+		First:         "09:00:00",
+		Last:          "09:23:00",
+		CodingSeconds: 120 + heartbeatTimeout + 60,
+		Gaps:          []HeartbeatGap{{Start: "09:02:00", End: "09:22:00", Seconds: 1200}},
+		Editors: []HeartbeatSource{
+			{Name: "vscode", Heartbeats: 2, First: "09:00:00", Last: "09:02:00"},
+			{Name: "Unknown", Heartbeats: 1, First: "09:23:00", Last: "09:23:00"},
+			{Name: "neovim", Heartbeats: 1, First: "09:22:00", Last: "09:22:00"},
+		},
+		Machines: []HeartbeatSource{
+			{Name: "desktop", Heartbeats: 2, First: "09:22:00", Last: "09:23:00"},
+			{Name: "laptop", Heartbeats: 2, First: "09:00:00", Last: "09:02:00"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeHeartbeats() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestWriteHeartbeatsCSV(t *testing.T) {
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	row := heartbeatRow(9*3600, "vscode", "laptop", true)
+	row.Entity, row.Type, row.Category = "/src/main.go", "file", "coding"
+	row.Project, row.Language, row.Branch = "wakafetch", "Go", "main"
+	row.Lines, row.LineNo, row.Dependencies = 120, 42, []string{"fmt", "os"}
+
+	var sb strings.Builder
+	if err := WriteHeartbeatsCSV(&sb, []HeartbeatRow{row}, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	want := "time\tentity\ttype\tcategory\tproject\tlanguage\tbranch\teditor\tmachine\tis_write\tlines\tlineno\tdependencies\n" +
+		"2026-10-19T09:00:00Z\t/src/main.go\tfile\tcoding\twakafetch\tGo\tmain\tvscode\tlaptop\ttrue\t120\t42\tfmt;os\n"
+	if sb.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
@@ -8,14 +8,44 @@ import (
 	"github.com/andatoshiki/wakafetch/wakafetch/types"
 )
 
+const numHeatmapLevels = 6 // 6 intensity levels matching GitHub style
+
+// Green RGB values for each intensity level (0-5)
+// Level 0: no activity (black), Level 5: maximum activity (light green)
+var greenLevels = [numHeatmapLevels]int{0, 30, 60, 100, 140, 220}
+
+// heatmapLevel maps a day's total to an intensity level (absolute thresholds)
+// Level 0: 0 hours (black)
+// Level 1: 0-2 hours
+// Level 2: 2-4 hours
+// Level 3: 4-6 hours
+// Level 4: 6-8 hours
+// Level 5: 8+ hours
+func heatmapLevel(seconds float64) int {
+	hours := seconds / 3600.0
+	switch {
+	case hours >= 8:
+		return 5
+	case hours >= 6:
+		return 4
+	case hours >= 4:
+		return 3
+	case hours >= 2:
+		return 2
+	case hours > 0:
+		return 1
+	}
+	return 0
+}
+
 func heatmap(days []types.DayData) ([]string, int) {
+	return heatmapWithSelection(days, "")
+}
+
+// heatmapWithSelection is heatmap with the day dated selected (YYYY-MM-DD) marked, for the TUI.
+func heatmapWithSelection(days []types.DayData, selected string) ([]string, int) {
 	const heatmapChar = "■"               // █ ❐ ▪ ◼ 🙩 🙫 ⛝ ⏹ 🞕 🞔 🞖
 	const highlight = "\x1b[38;2;0;%v;0m" // \x1b[38;2;R;G;Bm
-	const numLevels = 6                   // 6 intensity levels matching GitHub style
-
-	// Green RGB values for each intensity level (0-5)
-	// Level 0: no activity (black), Level 5: maximum activity (light green)
-	greenLevels := [numLevels]int{0, 30, 60, 100, 140, 220}
 
 	if len(days) == 0 {
 		return []string{}, 0
@@ -29,14 +59,9 @@ func heatmap(days []types.DayData) ([]string, int) {
 		return []string{}, 0
 	}
 
-	height := 4
 	numOfDays := int(endDay.Sub(startDay).Hours()/24) + 1
-	cols := getTerminalCols()
+	height := heatmapHeight(numOfDays)
 	width := 2*int((numOfDays+height-1)/height) - 1 // 2*ciel -1
-	for width+4 > cols {
-		height++
-		width = 2*int((numOfDays+height-1)/height) - 1
-	}
 
 	output := make([]string, height)
 	dataIndex := 0
@@ -46,30 +71,13 @@ func heatmap(days []types.DayData) ([]string, int) {
 		if dataIndex < len(days) && strings.Split(days[dataIndex].Range.Start, "T")[0] == d.Format("2006-01-02") {
 			day := days[dataIndex]
 			dataIndex++
-			// Convert seconds to hours
-			hours := day.GrandTotal.TotalSeconds / 3600.0
-			// Map hours to intensity level (absolute thresholds)
-			// Level 0: 0 hours (black)
-			// Level 1: 0-2 hours
-			// Level 2: 2-4 hours
-			// Level 3: 4-6 hours
-			// Level 4: 6-8 hours
-			// Level 5: 8+ hours
-			if hours >= 8 {
-				level = 5
-			} else if hours >= 6 {
-				level = 4
-			} else if hours >= 4 {
-				level = 3
-			} else if hours >= 2 {
-				level = 2
-			} else if hours > 0 {
-				level = 1
-			}
-			// hours == 0 remains level 0 (black)
+			level = heatmapLevel(day.GrandTotal.TotalSeconds)
 		}
 		greenValue := greenLevels[level]
 		char := fmt.Sprintf(highlight, greenValue) + heatmapChar + "\x1b[0m"
+		if d.Format("2006-01-02") == selected {
+			char = Clr.Bold + Clr.Yellow + heatmapChar + Clr.Reset
+		}
 		output[i%height] += char + " "
 		i++
 	}
@@ -89,3 +97,16 @@ func heatmap(days []types.DayData) ([]string, int) {
 	}
 	return output, width
 }
+
+// heatmapHeight is the number of rows the heatmap wraps numOfDays into: 4,
+// or more when a row of days wouldn't fit the terminal.
+func heatmapHeight(numOfDays int) int {
+	height := 4
+	cols := getTerminalCols()
+	width := 2*int((numOfDays+height-1)/height) - 1 // 2*ciel -1
+	for width+4 > cols && height < numOfDays {
+		height++
+		width = 2*int((numOfDays+height-1)/height) - 1
+	}
+	return height
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+// htmlReport is the data embedded as JSON in the HTML report; the page's script renders it.
+type htmlReport struct {
+	Heading    string          `json:"heading"`
+	Stats      []htmlField     `json:"stats"`
+	Dimensions []htmlDimension `json:"dimensions"`
+	Days       []htmlDay       `json:"days"` // oldest first
+}
+
+type htmlField struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+type htmlDimension struct {
+	Title string     `json:"title"`
+	Items []htmlItem `json:"items"`
+}
+
+type htmlItem struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+	Time    string  `json:"time"`
+	Percent float64 `json:"percent"`
+}
+
+type htmlDay struct {
+	Date        string          `json:"date"`
+	Seconds     float64         `json:"seconds"`
+	Time        string          `json:"time"`
+	Level       int             `json:"level"` // heatmap level, 0-5
+	TopLanguage string          `json:"topLanguage"`
+	TopProject  string          `json:"topProject"`
+	Dimensions  []htmlDimension `json:"dimensions"`
+}
+
+// WriteHTML writes a self-contained HTML report of a /summaries response:
+// the stats fields, a sortable table per dimension, the daily breakdown and
+// a calendar heatmap whose days (like the daily rows) open a per-day drill-down.
+func WriteHTML(w io.Writer, data *types.SummaryResponse, heading string, t Theme) error {
+	p := NewSummaryPayload(data, true, heading)
+	report := htmlReport{Heading: p.Heading, Dimensions: htmlDimensions(p)}
+	for _, f := range p.Stats {
+		report.Stats = append(report.Stats, htmlField{f.Key, f.Val})
+	}
+
+	days := make([]types.DayData, len(data.Data))
+	copy(days, data.Data)
+	sort.SliceStable(days, func(i, j int) bool {
+		return DatePart(days[i].Range.Start) < DatePart(days[j].Range.Start)
+	})
+	for _, day := range days {
+		d := newDay(day)
+		report.Days = append(report.Days, htmlDay{
+			Date:        d.Date,
+			Seconds:     d.Seconds,
+			Time:        d.Time,
+			Level:       heatmapLevel(d.Seconds),
+			TopLanguage: d.TopLanguage,
+			TopProject:  d.TopProject,
+			Dimensions: htmlDimensions(&DisplayPayload{
+				Languages:        day.Languages,
+				Projects:         day.Projects,
+				Editors:          day.Editors,
+				OperatingSystems: day.OperatingSystems,
+				Categories:       day.Categories,
+				Machines:         day.Machines,
+				Branches:         day.Branches,
+				Dependencies:     day.Dependencies,
+				Entities:         day.Entities,
+			}),
+		})
+	}
+
+	return reportTemplate.Execute(w, struct {
+		Title  string
+		Theme  Theme
+		Report htmlReport
+	}{"wakafetch: " + p.Heading, t, report})
+}
+
+// htmlDimensions returns the non-empty dimensions of p, in card order.
+func htmlDimensions(p *DisplayPayload) []htmlDimension {
+	dims := []htmlDimension{} // [] rather than null in the JSON
+	for _, name := range cardNames {
+		items := newItems(cardItems(p, name))
+		if len(items) == 0 {
+			continue
+		}
+		dim := htmlDimension{Title: cardTitles[name]}
+		for _, item := range items {
+			dim.Items = append(dim.Items, htmlItem(item))
+		}
+		dims = append(dims, dim)
+	}
+	return dims
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+var leaderColumns = []string{"Rank", "User", "Total", "Daily Avg", "Top Languages"}
+
+// DisplayLeaders prints a page of a leaderboard (board is its name, for the heading)
+// with the row of you highlighted: the user ID of the API key, for responses without
+// current_user ("" when unknown).
+func DisplayLeaders(data *types.LeadersResponse, board, you string) {
+	if len(data.Data) == 0 {
+		Warnln("No one on the leaderboard: '%s'", board)
+		return
+	}
+
+	if data.CurrentUser != nil && data.CurrentUser.User.ID != "" {
+		you = data.CurrentUser.User.ID
+	}
+	var info []Field
+	if data.Range.Text != "" {
+		info = append(info, Field{"Range", data.Range.Text})
+	}
+	if data.Language != "" {
+		info = append(info, Field{"Language", data.Language})
+	}
+	info = append(info, Field{"Your Rank", yourRank(data, you)})
+	info = append(info, Field{"Page", fmt.Sprintf("%d of %d", max(1, data.Page), max(1, data.TotalPages))})
+	if data.ModifiedAt != "" {
+		info = append(info, Field{"Updated", formatTimestamp(data.ModifiedAt)})
+	}
+	fields, fieldsWidth := fieldsStr(board, info)
+	card, _ := cardify(fields, "Leaderboard", fieldsWidth, 0)
+	printStrs(card)
+
+	rows, colors := leaderRows(data.Data, you)
+	lines, width := table{columns: leaderColumns, rows: rows, colors: colors, fit: 4, shorten: shortenText}.lines()
+	title := fmt.Sprintf("Page %d of %d", max(1, data.Page), max(1, data.TotalPages))
+	card, _ = cardify(lines, title, width, 0)
+	printStrs(card)
+}
+
+// yourRank is the rank of you from current_user, else from your row on this page.
+func yourRank(data *types.LeadersResponse, you string) string {
+	if data.CurrentUser != nil && data.CurrentUser.Rank > 0 {
+		return fmt.Sprintf("#%d (page %d)", data.CurrentUser.Rank, data.CurrentUser.Page)
+	}
+	for _, leader := range data.Data {
+		if you != "" && leader.User.ID == you {
+			return fmt.Sprintf("#%d (page %d)", leader.Rank, max(1, data.Page))
+		}
+	}
+	return "Not ranked"
+}
+
+// leaderRows returns the table rows of leaders and their colors, the row of you highlighted.
+func leaderRows(leaders []types.Leader, you string) ([][]string, []string) {
+	rows := make([][]string, 0, len(leaders))
+	colors := make([]string, 0, len(leaders))
+	for _, leader := range leaders {
+		name := leaderName(leader.User)
+		color := ""
+		if you != "" && leader.User.ID == you {
+			name += " (you)"
+			color = Clr.Bold + Clr.Green
+		}
+		languages := make([]string, 0, 3)
+		for _, lang := range leader.RunningTotal.Languages[:min(3, len(leader.RunningTotal.Languages))] {
+			languages = append(languages, lang.Name)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", leader.Rank),
+			name,
+			timeFmt(leader.RunningTotal.TotalSeconds),
+			timeFmt(leader.RunningTotal.DailyAverage),
+			strings.Join(languages, ", "),
+		})
+		colors = append(colors, color)
+	}
+	return rows, colors
+}
+
+// leaderName is the display name of a leader, with the username when it differs.
+func leaderName(user types.LeaderUser) string {
+	name := user.DisplayName
+	if name == "" {
+		name = user.FullName
+	}
+	switch {
+	case name == "":
+		return user.Username
+	case user.Username == "" || strings.EqualFold(name, user.Username) || strings.EqualFold(name, "@"+user.Username):
+		return name
+	default:
+		return fmt.Sprintf("%s (@%s)", name, user.Username)
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestLeaderName(t *testing.T) {
+	tests := []struct {
+		user types.LeaderUser
+		want string
+	}{
+		{types.LeaderUser{Username: "ada", DisplayName: "Ada Lovelace"}, "Ada Lovelace (@ada)"},
+		{types.LeaderUser{Username: "ada", FullName: "Ada Lovelace"}, "Ada Lovelace (@ada)"},
+		{types.LeaderUser{Username: "ada", DisplayName: "Countess", FullName: "Ada Lovelace"}, "Countess (@ada)"},
+		{types.LeaderUser{Username: "ada", DisplayName: "ADA"}, "ADA"},
+		{types.LeaderUser{Username: "ada", DisplayName: "@ada"}, "@ada"},
+		{types.LeaderUser{Username: "ada"}, "ada"},
+		{types.LeaderUser{DisplayName: "Anonymous User"}, "Anonymous User"},
+		{types.LeaderUser{}, ""},
+	}
+	for _, tt := range tests {
+		if got := leaderName(tt.user); got != tt.want {
+			t.Errorf("leaderName(%+v) = %q, want %q", tt.user, got, tt.want)
+		}
+	}
+}
+
+func testLeaders() *types.LeadersResponse {
+	data := &types.LeadersResponse{Page: 2, TotalPages: 5}
+	for i, name := range []string{"ada", "linus", "grace"} {
+		var leader types.Leader
+		leader.Rank = 51 + i
+		leader.User = types.LeaderUser{ID: "id-" + name, Username: name}
+		leader.RunningTotal.TotalSeconds = float64(3-i) * 36000
+		leader.RunningTotal.DailyAverage = float64(3-i) * 5400
+		leader.RunningTotal.Languages = statItems("Go", 100, "Rust", 50, "C", 20, "Zig", 10)[:3-i]
+		data.Data = append(data.Data, leader)
+	}
+	return data
+}
+
+func TestLeaderRows(t *testing.T) {
+	defer func(clr Colors) { Clr = clr }(Clr)
+	Clr = defaultColors
+	highlight := Clr.Bold + Clr.Green
+
+	data := testLeaders()
+	rows, colors := leaderRows(data.Data, "id-linus")
+	wantRows := [][]string{
+		{"#51", "ada", "30h 0m", "4h 30m", "Go, Rust, C"},
+		{"#52", "linus (you)", "20h 0m", "3h 0m", "Go, Rust"},
+		{"#53", "grace", "10h 0m", "1h 30m", "Go"},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("leaderRows() rows = %q, want %q", rows, wantRows)
+	}
+	if want := []string{"", highlight, ""}; !reflect.DeepEqual(colors, want) {
+		t.Errorf("leaderRows() colors = %q, want %q", colors, want)
+	}
+
+	for _, you := range []string{"", "id-nobody"} {
+		_, colors := leaderRows(data.Data, you)
+		if !reflect.DeepEqual(colors, []string{"", "", ""}) {
+			t.Errorf("leaderRows(%q) highlights %q", you, colors)
+		}
+	}
+}
+
+func TestYourRank(t *testing.T) {
+	data := testLeaders()
+	if got := yourRank(data, ""); got != "Not ranked" {
+		t.Errorf("yourRank() without current_user = %q", got)
+	}
+	if got := yourRank(data, "id-grace"); got != "#53 (page 2)" {
+		t.Errorf("yourRank() from the rows = %q", got)
+	}
+
+	data.CurrentUser = &struct {
+		Rank int              `json:"rank"`
+		Page int              `json:"page"`
+		User types.LeaderUser `json:"user"`
+	}{Rank: 1234, Page: 25, User: types.LeaderUser{ID: "id-me"}}
+	if got := yourRank(data, "id-grace"); got != "#1234 (page 25)" {
+		t.Errorf("yourRank() with current_user = %q", got)
+	}
+}
+
+func TestDisplayLeadersHighlight(t *testing.T) {
+	defer func(clr Colors) { Clr = clr }(Clr)
+	Clr = defaultColors
+	tests := []struct {
+		name        string
+		currentUser string // current_user's ID, "" for none
+		you         string
+		want        string // the highlighted user
+	}{
+		{"current_user", "id-ada", "", "ada"},
+		{"current_user over the fallback", "id-ada", "id-grace", "ada"},
+		{"fallback ID", "", "id-grace", "grace"},
+		{"nobody", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testLeaders()
+			if tt.currentUser != "" {
+				data.CurrentUser = &struct {
+					Rank int              `json:"rank"`
+					Page int              `json:"page"`
+					User types.LeaderUser `json:"user"`
+				}{User: types.LeaderUser{ID: tt.currentUser}}
+			}
+			text := CaptureText(func() { DisplayLeaders(data, "Public leaderboard", tt.you) })
+			found := ""
+			for _, name := range []string{"ada", "linus", "grace"} {
+				if strings.Contains(text, name+" (you)") {
+					found += name
+				}
+			}
+			if found != tt.want {
+				t.Errorf("highlighted %q, want %q:\n%s", found, tt.want, text)
+			}
+		})
+	}
+}
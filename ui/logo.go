@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Logo selects the logo of the --fetch layout.
+type Logo struct {
+	// Name is "auto" (the top language's logo, else the top editor's), "language",
+	// "editor", or a built-in logo. Empty means "auto".
+	Name string
+	// Lines is the art of a logo file, ANSI colors included. It takes precedence over Name.
+	Lines []string
+}
+
+// builtinLogo is ASCII art in one color, so the PNG font can draw it.
+type builtinLogo struct {
+	color func(Colors) string
+	art   string // starts with a newline, for readability
+}
+
+var builtinLogos = map[string]builtinLogo{
+	"wakatime": {func(c Colors) string { return c.Green }, `
+     .-========-.
+   .'            '.
+  /                \
+ |   \    /\    /   |
+ |    \  /  \  /    |
+  \    \/    \/    /
+   '.            .'
+     '-========-'`},
+	"go": {func(c Colors) string { return c.Cyan }, `
+   ______  ____
+  / ____/ / __ \
+ / / __  / / / /
+/ /_/ / / /_/ /
+\____/  \____/`},
+	"python": {func(c Colors) string { return c.Yellow }, `
+    ____
+   / __ \__  __
+  / /_/ / / / /
+ / ____/ /_/ /
+/_/    \__, /
+      /____/`},
+	"javascript": {func(c Colors) string { return c.Yellow }, `
+       _______
+      / / ___/
+ __  / /\__ \
+/ /_/ /___/ /
+\____//____/`},
+	"typescript": {func(c Colors) string { return c.Blue }, `
+  ___________
+ /_  __/ ___/
+  / /  \__ \
+ / /  ___/ /
+/_/  /____/`},
+	"rust": {func(c Colors) string { return c.Red }, `
+    ____
+   / __ \_____
+  / /_/ / ___/
+ / _, _(__  )
+/_/ |_/____/`},
+	"java": {func(c Colors) string { return c.Red }, `
+       __
+      / /___ __   ______ _
+ __  / / __ '/ | / / __ '/
+/ /_/ / /_/ /| |/ / /_/ /
+\____/\__,_/ |___/\__,_/`},
+	"vscode": {func(c Colors) string { return c.Blue }, `
+ _    _______
+| |  / / ___/
+| | / /\__ \
+| |/ /___/ /
+|___//____/`},
+	"vim": {func(c Colors) string { return c.Green }, `
+       _
+_   __(_)___ ___
+| | / / / __ '__ \
+| |/ / / / / / / /
+|___/_/_/ /_/ /_/`},
+}
+
+// logoAliases map language and editor names (lowercased) to their built-in logo.
+var logoAliases = map[string]string{
+	"golang":             "go",
+	"py":                 "python",
+	"js":                 "javascript",
+	"jsx":                "javascript",
+	"ts":                 "typescript",
+	"tsx":                "typescript",
+	"rs":                 "rust",
+	"vs code":            "vscode",
+	"visual studio code": "vscode",
+	"code":               "vscode",
+	"neovim":             "vim",
+	"nvim":               "vim",
+}
+
+// ParseLogo parses a --logo value: "auto", "language", "editor", a built-in logo,
+// or the path of a text file with the art ("~/" is the home directory, as the
+// config file's logo = isn't expanded by a shell).
+func ParseLogo(s string) (Logo, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	switch name {
+	case "", "auto", "language", "editor":
+		return Logo{Name: name}, nil
+	}
+	if _, ok := findLogo(name); ok {
+		return Logo{Name: name}, nil
+	}
+	content, err := os.ReadFile(expandHome(s))
+	if err != nil {
+		return Logo{}, fmt.Errorf("'%s' is neither a built-in logo (%s) nor a readable file", s, strings.Join(logoNames(), ", "))
+	}
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), "\n")
+	return Logo{Lines: lines}, nil
+}
+
+// expandHome replaces a leading "~" of path with the home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && rest[0] != '/' && rest[0] != filepath.Separator) {
+		return path // no "~", or "~user"
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+func logoNames() []string {
+	names := make([]string, 0, len(builtinLogos))
+	for name := range builtinLogos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func findLogo(name string) (builtinLogo, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := logoAliases[name]; ok {
+		name = alias
+	}
+	logo, ok := builtinLogos[name]
+	return logo, ok
+}
+
+// logoStr returns the lines of a built-in logo in its color, all as wide as the widest.
+func logoStr(name string) ([]string, int) {
+	logo, ok := findLogo(name)
+	if !ok {
+		logo = builtinLogos["wakatime"]
+	}
+	lines := strings.Split(strings.TrimPrefix(logo.art, "\n"), "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, displayWidth(line))
+	}
+	for i, line := range lines {
+		lines[i] = logo.color(Clr) + padVisible(line, width) + Clr.Reset
+	}
+	return lines, width
+}
+
+// fetchLogoStr resolves Opts.Logo for a payload: a logo file as is, or the built-in logo
+// of the top language or editor, falling back to wakatime's.
+func fetchLogoStr(p *DisplayPayload) ([]string, int) {
+	if lines := Opts.Logo.Lines; len(lines) > 0 {
+		width := 0
+		for _, line := range lines {
+			width = max(width, visibleWidth(line))
+		}
+		out := make([]string, len(lines))
+		for i, line := range lines {
+			if Clr.Reset == "" {
+				line = ansiEscape.ReplaceAllString(line, "")
+			}
+			out[i] = padVisible(line, width) + Clr.Reset
+		}
+		return out, width
+	}
+
+	var candidates []string
+	switch Opts.Logo.Name {
+	case "", "auto":
+		candidates = []string{topItemName(p.Languages, true), topItemName(p.Editors, true)}
+	case "language":
+		candidates = []string{topItemName(p.Languages, true)}
+	case "editor":
+		candidates = []string{topItemName(p.Editors, true)}
+	default:
+		candidates = []string{Opts.Logo.Name}
+	}
+	for _, name := range candidates {
+		if _, ok := findLogo(name); ok {
+			return logoStr(name)
+		}
+	}
+	return logoStr("wakatime")
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleWidth is the number of terminal columns of s, ignoring ANSI colors.
+func visibleWidth(s string) int {
+	return displayWidth(ansiEscape.ReplaceAllString(s, ""))
+}
+
+func padVisible(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-visibleWidth(s)))
+}
+
+// colorBlocksStr is neofetch's footer: a block of each terminal color, normal then bright.
+// It's empty without colors.
+func colorBlocksStr() []string {
+	if Clr.Reset == "" {
+		return nil
+	}
+	var normal, bright strings.Builder
+	for i := range 8 {
+		fmt.Fprintf(&normal, "\x1b[%dm███", 30+i)
+		fmt.Fprintf(&bright, "\x1b[%dm███", 90+i)
+	}
+	return []string{normal.String() + Clr.Reset, bright.String() + Clr.Reset}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLogo(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	art := "  /\\\n \x1b[31m/__\\\x1b[0m\r\n\n"
+	if err := os.WriteFile(filepath.Join(home, "art.txt"), []byte(art), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	artLines := []string{"  /\\", " \x1b[31m/__\\\x1b[0m"}
+
+	tests := []struct {
+		in      string
+		want    Logo
+		wantErr bool
+	}{
+		{"", Logo{Name: ""}, false},
+		{"auto", Logo{Name: "auto"}, false},
+		{" Language ", Logo{Name: "language"}, false},
+		{"editor", Logo{Name: "editor"}, false},
+		{"go", Logo{Name: "go"}, false},
+		{"Python", Logo{Name: "python"}, false},
+		{"golang", Logo{Name: "golang"}, false}, // alias of go
+		{"Visual Studio Code", Logo{Name: "visual studio code"}, false},
+		{filepath.Join(home, "art.txt"), Logo{Lines: artLines}, false},
+		{"~/art.txt", Logo{Lines: artLines}, false},
+		{"~/missing.txt", Logo{}, true},
+		{"~nobody/art.txt", Logo{}, true},
+		{"cobol", Logo{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogo(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogo(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLogo(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := ParseLogo("cobol"); err == nil || !strings.Contains(err.Error(), "go, java, javascript") {
+		t.Errorf("ParseLogo() error doesn't list the built-in logos: %v", err)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	tests := map[string]string{
+		"~":           "/home/me",
+		"~/art.txt":   "/home/me/art.txt",
+		"~/a/b.txt":   "/home/me/a/b.txt",
+		"~me/art.txt": "~me/art.txt",
+		"/tmp/~/art":  "/tmp/~/art",
+		"art.txt":     "art.txt",
+	}
+	for in, want := range tests {
+		if got := expandHome(in); got != want {
+			t.Errorf("expandHome(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFetchLogoStr(t *testing.T) {
+	defer func(opts Options, clr Colors) { Opts, Clr = opts, clr }(Opts, Clr)
+	Clr = defaultColors
+	p := &DisplayPayload{Languages: statItems("Golang", 300, "Cobol", 200), Editors: statItems("Neovim", 500)}
+	unknown := &DisplayPayload{Languages: statItems("Cobol", 300), Editors: statItems("Emacs", 500)}
+
+	tests := []struct {
+		name string
+		logo string
+		p    *DisplayPayload
+		want string // built-in logo
+	}{
+		{"auto: top language, through its alias", "auto", p, "go"},
+		{"empty is auto", "", p, "go"},
+		{"auto without a language logo: top editor", "auto", &DisplayPayload{Languages: statItems("Cobol", 300), Editors: statItems("nvim", 500)}, "vim"},
+		{"auto: unknown language is skipped", "auto", &DisplayPayload{Languages: statItems("unknown", 300, "Rust", 100)}, "rust"},
+		{"language", "language", p, "go"},
+		{"editor", "editor", p, "vim"},
+		{"language without a logo", "language", unknown, "wakatime"},
+		{"auto without any logo", "auto", unknown, "wakatime"},
+		{"built-in", "python", p, "python"},
+		{"alias", "vs code", p, "vscode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Opts.Logo = Logo{Name: tt.logo}
+			gotLines, gotWidth := fetchLogoStr(tt.p)
+			wantLines, wantWidth := logoStr(tt.want)
+			if !reflect.DeepEqual(gotLines, wantLines) || gotWidth != wantWidth {
+				t.Errorf("fetchLogoStr() = %q, want the %s logo %q", gotLines, tt.want, wantLines)
+			}
+		})
+	}
+
+	Opts.Logo = Logo{Lines: []string{"ab", "\x1b[31m日\x1b[0m", ""}}
+	lines, width := fetchLogoStr(p)
+	want := []string{"ab" + Clr.Reset, "\x1b[31m日\x1b[0m" + Clr.Reset, "  " + Clr.Reset}
+	if !reflect.DeepEqual(lines, want) || width != 2 {
+		t.Errorf("fetchLogoStr() of a file = %q, %d, want %q, 2", lines, width, want)
+	}
+
+	DisableColors()
+	lines, _ = fetchLogoStr(p)
+	if want := []string{"ab", "日", "  "}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("fetchLogoStr() of a file without colors = %q, want %q", lines, want)
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+const markdownBarWidth = 10
+
+// emoji per heatmap level, 0 (no activity) to 5 (8h+): greens that grow with the time,
+// like the green scale of the terminal and SVG heatmaps (there's only one green square emoji)
+var heatmapEmoji = [numHeatmapLevels]string{"⬜", "🌱", "🌿", "🍀", "🌳", "🌲"}
+
+// MarkdownOptions selects the optional sections of a Markdown report.
+type MarkdownOptions struct {
+	Daily   bool // daily breakdown table (summary data only)
+	Heatmap bool // emoji heatmap grid (summary data only)
+}
+
+// WriteMarkdown writes a Markdown report: the stats fields, a table per card
+// (all selected cards with p.Full, otherwise the first one), and optionally
+// the daily breakdown and heatmap of days.
+func WriteMarkdown(w io.Writer, p *DisplayPayload, days []types.DayData, opts MarkdownOptions) error {
+	var sb strings.Builder
+
+	sb.WriteString("## " + mdEscape(p.Heading) + "\n\n")
+	sb.WriteString("| Stat | Value |\n|------|-------|\n")
+	for _, f := range p.Stats {
+		sb.WriteString("| " + mdEscape(f.Key) + " | " + mdEscape(f.Val) + " |\n")
+	}
+
+	cards := selectedCards()
+	if !p.Full {
+		cards = cards[:1]
+	}
+	for _, spec := range cards {
+		items := cardItems(p, spec.Name)
+		if len(items) == 0 {
+			continue
+		}
+		sb.WriteString("\n### " + cardTitles[spec.Name] + "\n\n")
+		limit := cardLimit(spec)
+		if !p.Full {
+			limit = compactLimit(spec, len(p.Stats)) // like the compact terminal view
+		}
+		sb.WriteString(markdownItemsTable(items, limit))
+	}
+
+	if opts.Daily && len(days) > 0 {
+		sb.WriteString("\n### Daily\n\n")
+		sb.WriteString(markdownDailyTable(days))
+	}
+
+	if opts.Heatmap && len(days) > 0 {
+		sb.WriteString("\n### Heatmap\n\n")
+		sb.WriteString(markdownHeatmap(days))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownItemsTable(items []types.StatItem, limit int) string {
+	var sb strings.Builder
+	total := totalSeconds(items)
+	visible := collapseItems(items, limit, Opts.MinSeconds)
+	maxSeconds := 0.0
+	for _, item := range visible {
+		maxSeconds = max(maxSeconds, item.TotalSeconds)
+	}
+
+	sb.WriteString("| Name | Time | % | |\n|------|-----:|--:|---|\n")
+	for _, item := range visible {
+		pct := 0.0
+		if total > 0 {
+			pct = item.TotalSeconds / total * 100
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %.1f%% | %s |\n",
+			mdEscape(item.Name), timeFmt(item.TotalSeconds), pct, textBar(item.TotalSeconds, maxSeconds, markdownBarWidth, ""))
+	}
+	return sb.String()
+}
+
+func markdownDailyTable(days []types.DayData) string {
+	var sb strings.Builder
+	sorted := make([]types.DayData, len(days))
+	copy(sorted, days)
+	sort.Slice(sorted, func(i, j int) bool {
+		return DatePart(sorted[i].Range.Start) > DatePart(sorted[j].Range.Start)
+	})
+	maxSecs := findMaxDailySeconds(sorted)
+
+	sb.WriteString("| Date | Time | | Language | Project |\n|------|-----:|---|----------|---------|\n")
+	for _, day := range sorted {
+		if day.GrandTotal.TotalSeconds < Opts.MinSeconds {
+			continue
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
+			DatePart(day.Range.Start),
+			timeFmt(day.GrandTotal.TotalSeconds),
+			textBar(day.GrandTotal.TotalSeconds, maxSecs, markdownBarWidth, ""),
+			mdEscape(topItemName(day.Languages, false)),
+			mdEscape(topItemName(day.Projects, true)))
+	}
+	return sb.String()
+}
+
+// markdownHeatmap renders a GitHub style calendar: a row per weekday, a column per week.
+func markdownHeatmap(days []types.DayData) string {
+	seconds := make(map[string]float64, len(days))
+	for _, day := range days {
+		seconds[DatePart(day.Range.Start)] = day.GrandTotal.TotalSeconds
+	}
+	start, err1 := time.Parse("2006-01-02", DatePart(days[0].Range.Start))
+	end, err2 := time.Parse("2006-01-02", DatePart(days[len(days)-1].Range.Start))
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return ""
+	}
+
+	// weeks start on Monday
+	first := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	var weeks []time.Time
+	for d := first; !d.After(end); d = d.AddDate(0, 0, 7) {
+		weeks = append(weeks, d)
+	}
+
+	var sb strings.Builder
+	// header: month label on the first week of each month
+	sb.WriteString("| |")
+	lastMonth := time.Month(0)
+	for _, week := range weeks {
+		label := ""
+		if week.Month() != lastMonth {
+			label = week.Format("Jan")
+			lastMonth = week.Month()
+		}
+		sb.WriteString(" " + label + " |")
+	}
+	sb.WriteString("\n|---|" + strings.Repeat(":-:|", len(weeks)) + "\n")
+
+	for weekday := range 7 {
+		sb.WriteString("| " + first.AddDate(0, 0, weekday).Format("Mon") + " |")
+		for _, week := range weeks {
+			d := week.AddDate(0, 0, weekday)
+			cell := ""
+			if !d.Before(start) && !d.After(end) {
+				cell = heatmapEmoji[heatmapLevel(seconds[d.Format("2006-01-02")])]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n" + heatmapEmoji[0] + " none · " + heatmapEmoji[1] + " <2h · " + heatmapEmoji[2] + " 2-4h · " +
+		heatmapEmoji[3] + " 4-6h · " + heatmapEmoji[4] + " 6-8h · " + heatmapEmoji[5] + " 8h+\n")
+	return sb.String()
+}
+
+// mdEscape escapes characters that would break a Markdown table cell.
+func mdEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
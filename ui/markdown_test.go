@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdownLimit(t *testing.T) {
+	defer func(opts Options) { Opts = opts }(Opts)
+	Opts = Options{Cards: []CardSpec{{Name: "languages"}, {Name: "editors"}}}
+
+	p := &DisplayPayload{
+		Heading:   "Last 7 days",
+		Stats:     []Field{{"Total Time", "5h 0m"}, {"Daily Avg", "43m 0s"}, {"Top Language", "Go"}},
+		Languages: statItems("Go", 5000, "Rust", 4000, "C", 3000, "Zig", 2000, "Lua", 1000),
+		Editors:   statItems("Neovim", 15000),
+	}
+	tests := []struct {
+		name     string
+		full     bool
+		limit    int // --limit
+		cards    []CardSpec
+		want     []string
+		wantNone []string
+	}{
+		{"compact: as many rows as stats", false, 0, nil, []string{"| Go |", "| Rust |", "| C |", "| Other |"}, []string{"| Zig |", "### Editors"}},
+		{"compact with --limit", false, 1, nil, []string{"| Go |", "| Other |"}, []string{"| Rust |"}},
+		{"compact with a card limit", false, 0, []CardSpec{{Name: "languages", Limit: 4}}, []string{"| Zig |", "| Other |"}, []string{"| Lua |"}},
+		{"full: all items", true, 0, nil, []string{"| Lua |", "### Editors"}, []string{"| Other |"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Opts.Limit = tt.limit
+			Opts.Cards = []CardSpec{{Name: "languages"}, {Name: "editors"}}
+			if tt.cards != nil {
+				Opts.Cards = tt.cards
+			}
+			p.Full = tt.full
+			var sb strings.Builder
+			if err := WriteMarkdown(&sb, p, nil, MarkdownOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(sb.String(), s) {
+					t.Errorf("missing %q in\n%s", s, sb.String())
+				}
+			}
+			for _, s := range tt.wantNone {
+				if strings.Contains(sb.String(), s) {
+					t.Errorf("unexpected %q in\n%s", s, sb.String())
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownHeatmap(t *testing.T) {
+	// 2026-10-05 is a Monday
+	got := markdownHeatmap(daysFrom("2026-10-05", 0, 3600, 2*3600, 4*3600, 6*3600, 8*3600, 30))
+	for i, line := range []string{
+		"| Mon | ⬜ |",
+		"| Tue | 🌱 |",
+		"| Wed | 🌿 |",
+		"| Thu | 🍀 |",
+		"| Fri | 🌳 |",
+		"| Sat | 🌲 |",
+		"| Sun | 🌱 |",
+		"⬜ none · 🌱 <2h · 🌿 2-4h · 🍀 4-6h · 🌳 6-8h · 🌲 8h+",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("line %d: missing %q in\n%s", i, line, got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// DisplayMe prints the account overview card: the wakatime logo next to the account's fields,
+// headed by username@host like neofetch. allTime may be nil when it couldn't be fetched.
+func DisplayMe(user types.User, allTime *types.AllTimeSinceTodayResponse, host string) {
+	fields, fieldsWidth := fieldsStr(user.Username+"@"+host, meFields(user, allTime))
+
+	logo, logoWidth := logoStr("wakatime")
+	lines, width := fields, fieldsWidth
+	// the logo goes when there's no room for it
+	if getTerminalCols() >= logoWidth+2+fieldsWidth+4 {
+		lines = make([]string, max(len(logo), len(fields)))
+		logoTop := max(0, (len(fields)-len(logo))/2)
+		for i := range lines {
+			left := strings.Repeat(" ", logoWidth)
+			if i >= logoTop && i-logoTop < len(logo) {
+				left = logo[i-logoTop]
+			}
+			field := strings.Repeat(" ", fieldsWidth)
+			if i < len(fields) {
+				field = fields[i]
+			}
+			lines[i] = left + "  " + field
+		}
+		width = logoWidth + 2 + fieldsWidth
+	}
+
+	card, _ := cardify(lines, "wakafetch", width, 0)
+	printStrs(card)
+}
+
+func meFields(user types.User, allTime *types.AllTimeSinceTodayResponse) []Field {
+	var fields []Field
+	add := func(key, val string) {
+		if val != "" {
+			fields = append(fields, Field{key, val})
+		}
+	}
+
+	name := user.DisplayName
+	if name == "" || name == user.Username {
+		name = user.FullName
+	}
+	if name != user.Username {
+		add("Name", name)
+	}
+	plan := user.Plan
+	if plan != "" {
+		plan = strings.ToUpper(plan[:1]) + plan[1:]
+	}
+	add("Plan", plan)
+	add("Timezone", user.Timezone)
+	if user.CreatedAt != "" {
+		add("Member Since", formatBestDay(user.CreatedAt)+accountAge(user.CreatedAt))
+	}
+
+	if allTime != nil {
+		total := timeFmt(allTime.Data.TotalSeconds)
+		if !allTime.Data.IsUpToDate && allTime.Data.PercentCalculated > 0 && allTime.Data.PercentCalculated < 100 {
+			total += fmt.Sprintf(" (%d%% calculated)", allTime.Data.PercentCalculated)
+		}
+		add("Total Time", total)
+		if allTime.Data.DailyAverage > 0 {
+			add("Daily Average", timeFmt(allTime.Data.DailyAverage))
+		}
+		if start := allTime.Data.Range.StartDate; start != "" {
+			add("First Day", formatBestDay(start))
+		}
+	}
+
+	if user.LastHeartbeatAt != "" {
+		last := formatTimestamp(user.LastHeartbeatAt)
+		var where []string
+		for _, s := range []string{user.LastProject, user.LastPluginName} {
+			if s != "" {
+				where = append(where, s)
+			}
+		}
+		if len(where) > 0 {
+			last += " (" + strings.Join(where, ", ") + ")"
+		}
+		add("Last Coded", last)
+	}
+
+	add("Location", user.Location)
+	add("Website", user.Website)
+	if user.GithubUsername != "" {
+		add("GitHub", "github.com/"+user.GithubUsername)
+	}
+	profile := profileVisibility(user)
+	add("Profile", profile)
+	if profile != "Private" {
+		add("Profile URL", user.ProfileURL)
+	}
+	add("Photo", user.Photo)
+	return fields
+}
+
+// accountAge is " (3 years)" for an account created 3 years ago, "" under a year.
+func accountAge(createdAt string) string {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return ""
+	}
+	years := time.Now().Year() - created.Year()
+	if time.Now().YearDay() < created.YearDay() {
+		years--
+	}
+	switch {
+	case years < 1:
+		return ""
+	case years == 1:
+		return " (1 year)"
+	default:
+		return fmt.Sprintf(" (%d years)", years)
+	}
+}
+
+// formatTimestamp formats an RFC 3339 timestamp as local "Jan 2 2006, 15:04".
+func formatTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("Jan 2 2006, 15:04")
+}
+
+// profileVisibility lists what the public profile shows: "Public (time, languages)",
+// or "Private" when nothing is public.
+func profileVisibility(user types.User) string {
+	var public []string
+	for _, p := range []struct {
+		name string
+		on   bool
+	}{
+		{"time", user.LoggedTimePublic},
+		{"languages", user.LanguagesUsedPublic},
+		{"photo", user.PhotoPublic},
+		{"email", user.IsEmailPublic},
+	} {
+		if p.on {
+			public = append(public, p.name)
+		}
+	}
+	if len(public) == 0 {
+		return "Private"
+	}
+	return "Public (" + strings.Join(public, ", ") + ")"
+}
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// CardSpec selects a card for the --full layout and how many items it lists.
+// A Limit of 0 means the card's default limit.
+type CardSpec struct {
+	Name  string
+	Limit int
+}
+
+// Options holds user display preferences (from config and flags).
+// Empty values mean the built-in defaults.
+type Options struct {
+	Cards  []CardSpec
+	Fields []string
+	// Limit caps the rows of every card (unless the card sets its own limit).
+	Limit int
+	// MinSeconds is the threshold below which items are folded into "Other".
+	MinSeconds float64
+	// Theme is the name of the image exporters' theme (see Themes).
+	Theme string
+	// Fetch replaces the compact view with the neofetch-like layout, Logo next to the stats.
+	Fetch bool
+	Logo  Logo
+}
+
+var Opts = Options{MinSeconds: 60}
+
+// card names in the order they're listed in help/errors
+var cardNames = []string{"languages", "projects", "editors", "branches", "dependencies", "categories", "machines", "os", "entities"}
+
+var cardTitles = map[string]string{
+	"languages":    "Languages",
+	"projects":     "Projects",
+	"editors":      "Editors",
+	"branches":     "Branches",
+	"dependencies": "Dependencies",
+	"categories":   "Categories",
+	"machines":     "Machines",
+	"os":           "Operating Systems",
+	"entities":     "Entities",
+}
+
+var cardAliases = map[string]string{
+	"language":          "languages",
+	"project":           "projects",
+	"editor":            "editors",
+	"branch":            "branches",
+	"dependency":        "dependencies",
+	"category":          "categories",
+	"machine":           "machines",
+	"operating_systems": "os",
+	"entity":            "entities",
+	"files":             "entities",
+}
+
+var defaultCardLimits = map[string]int{
+	"dependencies": 10,
+	"entities":     5,
+}
+
+// order matters: even indexes go left, odd ones right (below Stats)
+var defaultCards = []CardSpec{
+	{Name: "languages"},
+	{Name: "editors"},
+	{Name: "projects"},
+	{Name: "os"},
+	{Name: "categories"},
+	{Name: "machines"},
+	{Name: "branches"},
+	{Name: "dependencies"},
+	{Name: "entities"},
+}
+
+// field names in the order they're listed in help/errors
+var fieldNames = []string{"total", "avg", "active", "best", "streak", "language", "project", "editor", "os", "category", "machine", "languages", "projects"}
+
+var defaultFields = []string{"total", "avg", "active", "best", "project", "editor", "os", "languages", "projects"}
+
+// ParseCards parses a comma separated card list like "languages:10,projects".
+func ParseCards(s string) ([]CardSpec, error) {
+	var cards []CardSpec
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		name, limitStr, hasLimit := strings.Cut(part, ":")
+		if alias, ok := cardAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := cardTitles[name]; !ok {
+			return nil, fmt.Errorf("unknown card '%s', must be one of: %s", name, strings.Join(cardNames, ", "))
+		}
+		spec := CardSpec{Name: name}
+		if hasLimit {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("invalid limit for card '%s': %s", name, limitStr)
+			}
+			spec.Limit = limit
+		}
+		cards = append(cards, spec)
+	}
+	return cards, nil
+}
+
+// ParseFields parses a comma separated list of stats field names like "total,avg,streak".
+func ParseFields(s string) ([]string, error) {
+	var fields []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if !isFieldName(part) {
+			return nil, fmt.Errorf("unknown field '%s', must be one of: %s", part, strings.Join(fieldNames, ", "))
+		}
+		fields = append(fields, part)
+	}
+	return fields, nil
+}
+
+// NeedsDailyData reports whether the selected fields can only be computed from per-day data.
+func (o Options) NeedsDailyData() bool {
+	for _, f := range o.Fields {
+		if f == "streak" {
+			return true
+		}
+	}
+	return false
+}
+
+func isFieldName(name string) bool {
+	for _, f := range fieldNames {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectFields picks fields in the user's order (or the defaults), skipping ones
+// that aren't available for the current data.
+func selectFields(available map[string]Field) []Field {
+	order := Opts.Fields
+	if len(order) == 0 {
+		order = defaultFields
+	}
+	fields := make([]Field, 0, len(order))
+	for _, name := range order {
+		if f, ok := available[name]; ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func selectedCards() []CardSpec {
+	if len(Opts.Cards) == 0 {
+		return defaultCards
+	}
+	return Opts.Cards
+}
+
+// rotateCards returns cards starting at the first-th one, wrapping around; cards isn't modified.
+func rotateCards(cards []CardSpec, first int) []CardSpec {
+	if len(cards) < 2 || first%len(cards) == 0 {
+		return cards
+	}
+	first %= len(cards)
+	return append(append(make([]CardSpec, 0, len(cards)), cards[first:]...), cards[:first]...)
+}
+
+func cardLimit(spec CardSpec) int {
+	if spec.Limit > 0 {
+		return spec.Limit
+	}
+	if Opts.Limit > 0 {
+		return Opts.Limit
+	}
+	return defaultCardLimits[spec.Name]
+}
+
+// compactLimit is the limit of the card the compact view shows next to the stats:
+// as many rows as the stats (rows) by default.
+func compactLimit(spec CardSpec, rows int) int {
+	if limit := cardLimit(spec); limit > 0 {
+		return limit
+	}
+	return rows
+}
+
+func cardItems(p *DisplayPayload, name string) []types.StatItem {
+	switch name {
+	case "languages":
+		return p.Languages
+	case "projects":
+		return p.Projects
+	case "editors":
+		return p.Editors
+	case "branches":
+		return p.Branches
+	case "dependencies":
+		return p.Dependencies
+	case "categories":
+		return p.Categories
+	case "machines":
+		return p.Machines
+	case "os":
+		return p.OperatingSystems
+	case "entities":
+		return p.Entities
+	}
+	return nil
+}
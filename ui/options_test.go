@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []CardSpec
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"languages", []CardSpec{{Name: "languages"}}, false},
+		{"languages:10, projects", []CardSpec{{Name: "languages", Limit: 10}, {Name: "projects"}}, false},
+		{"Language,operating_systems,files:3", []CardSpec{{Name: "languages"}, {Name: "os"}, {Name: "entities", Limit: 3}}, false},
+		{"languages,,editors,", []CardSpec{{Name: "languages"}, {Name: "editors"}}, false},
+		{"languages:0", []CardSpec{{Name: "languages"}}, false},
+		{"colors", nil, true},
+		{"languages:ten", nil, true},
+		{"languages:-1", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseCards(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCards(%q) error = %v, want error: %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCards(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"total,avg,streak", []string{"total", "avg", "streak"}, false},
+		{" Total , AVG ,", []string{"total", "avg"}, false},
+		{"total,speed", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFields(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFields(%q) error = %v, want error: %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFields(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsDailyData(t *testing.T) {
+	if (Options{Fields: []string{"total", "avg"}}).NeedsDailyData() {
+		t.Error("NeedsDailyData() = true without streak")
+	}
+	if !(Options{Fields: []string{"total", "streak"}}).NeedsDailyData() {
+		t.Error("NeedsDailyData() = false with streak")
+	}
+}
+
+func TestRotateCards(t *testing.T) {
+	cards := []CardSpec{{Name: "languages"}, {Name: "projects", Limit: 3}, {Name: "editors"}}
+	tests := []struct {
+		first int
+		want  []string
+	}{
+		{0, []string{"languages", "projects", "editors"}},
+		{1, []string{"projects", "editors", "languages"}},
+		{2, []string{"editors", "languages", "projects"}},
+		{3, []string{"languages", "projects", "editors"}},
+		{4, []string{"projects", "editors", "languages"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, card := range rotateCards(cards, tt.first) {
+			got = append(got, card.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("rotateCards(%d) = %v, want %v", tt.first, got, tt.want)
+		}
+	}
+	if cards[0].Name != "languages" || cards[1].Limit != 3 {
+		t.Errorf("rotateCards modified its input: %v", cards)
+	}
+}
+
+func TestCurrentCard(t *testing.T) {
+	defer func(cards []CardSpec) { Opts.Cards = cards }(Opts.Cards)
+	Opts.Cards = []CardSpec{{Name: "languages"}, {Name: "projects"}}
+	for card, want := range []string{"Languages", "Projects", "Languages"} {
+		if got := CurrentCard(card); got != want {
+			t.Errorf("CurrentCard(%d) = %q, want %q", card, got, want)
+		}
+	}
+	if CardCount() != 2 || Opts.Cards[0].Name != "languages" {
+		t.Errorf("CardCount() = %d, Opts.Cards = %v", CardCount(), Opts.Cards)
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// OrgDashboards is an organization with the dashboards the user can see.
+type OrgDashboards struct {
+	Org        types.Org         `json:"org"`
+	Dashboards []types.Dashboard `json:"dashboards"`
+}
+
+// MemberTotal is the time of a dashboard's member over a range.
+type MemberTotal struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Username     string  `json:"username"`
+	TotalSeconds float64 `json:"total_seconds"`
+	TopProject   string  `json:"top_project"`
+	TopLanguage  string  `json:"top_language"`
+}
+
+// NewMemberTotal sums a member's /summaries.
+func NewMemberTotal(member types.DashboardMember, data *types.SummaryResponse) MemberTotal {
+	name := member.FullName
+	if name == "" {
+		name = member.Username
+	}
+	total := MemberTotal{ID: member.ID, Name: name, Username: member.Username}
+	for _, day := range data.Data {
+		total.TotalSeconds += day.GrandTotal.TotalSeconds
+	}
+	p := aggregateDays(data.Data)
+	if len(p.Projects) > 0 {
+		total.TopProject = topItemName(p.Projects, true)
+	}
+	if len(p.Languages) > 0 {
+		total.TopLanguage = topItemName(p.Languages, true)
+	}
+	return total
+}
+
+// CombineSummaries adds up the /summaries of the same range (the members of a dashboard)
+// into one, day by day and item by item.
+func CombineSummaries(responses []*types.SummaryResponse) *types.SummaryResponse {
+	combined := &types.SummaryResponse{}
+	var dates []string
+	days := map[string][]types.DayData{}
+	for _, r := range responses {
+		if combined.Start == "" {
+			combined.Start, combined.End = r.Start, r.End
+		}
+		for _, day := range r.Data {
+			if _, ok := days[day.Range.Date]; !ok {
+				dates = append(dates, day.Range.Date)
+			}
+			days[day.Range.Date] = append(days[day.Range.Date], day)
+		}
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		day := types.DayData{Range: days[date][0].Range}
+		for _, d := range days[date] {
+			day.GrandTotal.TotalSeconds += d.GrandTotal.TotalSeconds
+		}
+		day.GrandTotal.Text = timeFmt(day.GrandTotal.TotalSeconds)
+		p := aggregateDays(days[date])
+		day.Languages, day.Projects, day.Editors = p.Languages, p.Projects, p.Editors
+		day.OperatingSystems, day.Categories, day.Machines = p.OperatingSystems, p.Categories, p.Machines
+		day.Entities, day.Branches, day.Dependencies = p.Entities, p.Branches, p.Dependencies
+		combined.Data = append(combined.Data, day)
+		combined.CumulativeTotal.Seconds += day.GrandTotal.TotalSeconds
+		if day.GrandTotal.TotalSeconds > 0 {
+			combined.DailyAverage.DaysMinusHolidays++
+		}
+	}
+	combined.CumulativeTotal.Text = timeFmt(combined.CumulativeTotal.Seconds)
+	// like WakaTime's, the average skips the days without activity
+	avg := &combined.DailyAverage
+	avg.DaysIncludingHolidays = len(dates)
+	avg.Holidays = avg.DaysIncludingHolidays - avg.DaysMinusHolidays
+	if avg.DaysMinusHolidays > 0 {
+		avg.Seconds = combined.CumulativeTotal.Seconds / float64(avg.DaysMinusHolidays)
+	}
+	avg.Text = timeFmt(avg.Seconds)
+	return combined
+}
+
+// DisplayOrgs prints a card per organization listing its dashboards.
+func DisplayOrgs(orgs []OrgDashboards) {
+	if len(orgs) == 0 {
+		Warnln("You aren't in any organization")
+		return
+	}
+	for _, o := range orgs {
+		var lines []string
+		width := 0
+		if len(o.Dashboards) == 0 {
+			lines, width = []string{"No dashboards"}, len("No dashboards")
+		} else {
+			rows := make([][]string, 0, len(o.Dashboards))
+			for _, d := range o.Dashboards {
+				rows = append(rows, []string{d.Name, d.ID, fmt.Sprintf("%d", d.MembersCount), d.Timezone})
+			}
+			lines, width = table{columns: []string{"Dashboard", "ID", "Members", "Timezone"}, rows: rows, fit: -1}.lines()
+		}
+		card, _ := cardify(lines, o.Org.Name, width, 0)
+		printStrs(card)
+	}
+}
+
+// DisplayMembers prints the time of each member of a dashboard as a card, like the --full cards.
+func DisplayMembers(members []MemberTotal, dashboard, heading string) {
+	items := make([]types.StatItem, 0, len(members))
+	for _, m := range members {
+		items = append(items, types.StatItem{Name: m.Name, TotalSeconds: m.TotalSeconds})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].TotalSeconds > items[j].TotalSeconds })
+	lines, width := graphStr(items, 0)
+	if len(lines) == 0 || totalSeconds(items) == 0 {
+		Warnln("No member of '%s' has coded in the selected period: '%s'", dashboard, heading)
+		return
+	}
+	card, _ := cardify(lines, "Members of "+dashboard, width, 0)
+	printStrs(card)
+}
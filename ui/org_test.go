@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestCombineSummaries(t *testing.T) {
+	alice := &types.SummaryResponse{Data: daysOf(3600, 0, 1800)}
+	alice.Start, alice.End = alice.Data[0].Range.Start, alice.Data[2].Range.End
+	alice.Data[0].Languages = statItems("Go", 3600)
+	alice.Data[2].Languages = statItems("Go", 1200, "Rust", 600)
+	bob := &types.SummaryResponse{Data: daysOf(600, 0, 0)}
+	bob.Data[0].Languages = statItems("Go", 300, "Python", 300)
+
+	got := CombineSummaries([]*types.SummaryResponse{alice, bob})
+	if got.Start != alice.Start || got.End != alice.End {
+		t.Errorf("range = %s to %s, want %s to %s", got.Start, got.End, alice.Start, alice.End)
+	}
+	var totals []float64
+	for _, day := range got.Data {
+		totals = append(totals, day.GrandTotal.TotalSeconds)
+	}
+	if want := []float64{4200, 0, 1800}; !reflect.DeepEqual(totals, want) {
+		t.Errorf("daily totals = %v, want %v", totals, want)
+	}
+	if want := statItems("Go", 3900, "Python", 300); !reflect.DeepEqual(got.Data[0].Languages, want) {
+		t.Errorf("first day's languages = %v, want %v", got.Data[0].Languages, want)
+	}
+	if got.CumulativeTotal.Seconds != 6000 {
+		t.Errorf("cumulative total = %v, want 6000", got.CumulativeTotal.Seconds)
+	}
+	// the average skips the day without activity
+	avg := got.DailyAverage
+	if avg.Seconds != 3000 || avg.DaysIncludingHolidays != 3 || avg.DaysMinusHolidays != 2 || avg.Holidays != 1 {
+		t.Errorf("daily average = %+v, want 3000s over 2 of 3 days", avg)
+	}
+}
+
+func TestCombineSummariesEmpty(t *testing.T) {
+	got := CombineSummaries(nil)
+	if len(got.Data) != 0 || got.CumulativeTotal.Seconds != 0 || got.DailyAverage.Seconds != 0 {
+		t.Errorf("CombineSummaries(nil) = %+v, want an empty summary", got)
+	}
+}
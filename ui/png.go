@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+// A cell is 6x12 font pixels: the 5x9 glyph, a column of spacing, a row above and two below.
+const (
+	pngCellW   = glyphW + 1
+	pngCellH   = glyphH + 3
+	pngPadding = 8
+)
+
+func pngMetrics(scale int) metrics {
+	s := float64(scale)
+	return metrics{cellW: pngCellW * s, cellH: pngCellH * s, padding: pngPadding * s}
+}
+
+type pngCanvas struct {
+	img   *image.RGBA
+	scale int
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, hex string) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(parseHex(hex)), image.Point{}, draw.Src)
+}
+
+func (c *pngCanvas) text(x, y float64, s string, hex string, bold bool) {
+	col := image.NewUniform(parseHex(hex))
+	px := c.scale
+	cellX := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if w == 0 {
+			continue // the font has no combining marks
+		}
+		g, ok := glyphs[r]
+		if !ok {
+			g = missingGlyph
+		}
+		x0 := int(x) + cellX*pngCellW*px
+		cellX += w
+		y0 := int(y) + px // one row above the glyph
+		for row, bits := range g {
+			// widen to the whole cell, bit 5 is the leftmost pixel
+			cells := uint(bits) << 1
+			if bold {
+				// smear one pixel right, into the spacing column
+				cells |= cells >> 1
+			}
+			for bit := range pngCellW {
+				if cells&(1<<(pngCellW-1-bit)) == 0 {
+					continue
+				}
+				r := image.Rect(x0+bit*px, y0+row*px, x0+(bit+1)*px, y0+(row+1)*px)
+				draw.Draw(c.img, r, col, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+// parseHex parses "#rrggbb", falling back to black.
+func parseHex(hex string) color.RGBA {
+	v, err := strconv.ParseUint(hex[min(1, len(hex)):], 16, 32)
+	if err != nil || len(hex) != 7 {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// WritePNG rasterizes the captured screen with the built-in bitmap font,
+// scale pixels per font pixel.
+func (s *Screen) WritePNG(w io.Writer, t Theme, scale int) error {
+	m := pngMetrics(scale)
+	width, height := m.size(s)
+	cv := &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, int(width), int(height))), scale: scale}
+	drawScreen(cv, s, t, m)
+	return png.Encode(w, cv.img)
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// projectCards are the cards of the project view, the stats and trend aside.
+var projectCards = []CardSpec{
+	{Name: "languages"},
+	{Name: "branches"},
+	{Name: "editors"},
+	{Name: "entities"},
+	{Name: "categories"},
+}
+
+// DisplayProject prints one project over a range: its stats, the daily (or weekly) trend
+// and the languages, branches, editors, files and categories within it.
+// data is a /summaries response filtered by the project.
+func DisplayProject(data *types.SummaryResponse, project, heading string) {
+	total := 0.0
+	if data != nil {
+		for _, day := range data.Data {
+			total += day.GrandTotal.TotalSeconds
+		}
+	}
+	if total == 0 {
+		Warnln("No activity in project '%s' for the selected period: '%s'", project, heading)
+		return
+	}
+
+	payload := aggregateDays(data.Data)
+	fields, fieldsWidth := fieldsStr(project+" ("+formatDateRange(data.Start, data.End)+")", projectFields(data.Data, payload, total))
+	statsCard := CardConfig{Title: heading, Lines: fields, Width: fieldsWidth}
+
+	trendTitle, trendItems := projectTrend(data.Data)
+	trendLines, trendWidth := graphLines(trendItems, total)
+	trendCard := CardConfig{Title: trendTitle, Lines: trendLines, Width: trendWidth}
+
+	shrink := getTerminalCols() < 96
+	var section CardSection
+	if shrink {
+		section.Left = append(section.Left, statsCard, trendCard)
+	} else {
+		section.Left = append(section.Left, trendCard)
+		section.Right = append(section.Right, statsCard)
+	}
+	for i, spec := range projectCards {
+		lines, width := graphStr(cardItems(payload, spec.Name), cardLimit(spec))
+		if len(lines) == 0 {
+			continue // e.g. no branches outside of git
+		}
+		card := CardConfig{Title: cardTitles[spec.Name], Lines: lines, Width: width}
+		if shrink || i%2 == 0 {
+			section.Left = append(section.Left, card)
+		} else {
+			section.Right = append(section.Right, card)
+		}
+	}
+	renderCardSection(section)
+}
+
+func projectFields(days []types.DayData, p *DisplayPayload, total float64) []Field {
+	fields := []Field{{"Total Time", timeFmt(total)}}
+	if len(days) > 1 {
+		active := 0
+		for _, day := range days {
+			if day.GrandTotal.TotalSeconds > 0 {
+				active++
+			}
+		}
+		busiestDay, busiestDaySeconds := findBusiestDay(days)
+		fields = append(fields,
+			Field{"Daily Avg", timeFmt(total / float64(len(days)))},
+			Field{"Active Days", fmt.Sprintf("%d/%d days", active, len(days))},
+			Field{"Best Day", fmt.Sprintf("%s (%s)", formatBestDay(busiestDay), timeFmt(busiestDaySeconds))},
+		)
+	}
+	fields = append(fields,
+		Field{"Top Language", topItemName(p.Languages, false)},
+		Field{"Top Branch", topItemName(p.Branches, false)},
+		Field{"Top Editor", topItemName(p.Editors, false)},
+		Field{"Files", fmt.Sprintf("%d", len(p.Entities))},
+	)
+	return fields
+}
+
+// projectTrend returns the trend card's title and rows, oldest first: the days with at least
+// --min of activity, or weeks (by their Monday) when the range is longer than a month.
+func projectTrend(days []types.DayData) (string, []types.StatItem) {
+	title := "Daily"
+	var items []types.StatItem
+	for _, day := range days {
+		label := formatDailyDate(day.Range.Start)
+		if len(days) > 31 {
+			title = "Weekly"
+			if date, err := time.Parse("2006-01-02", DatePart(day.Range.Start)); err == nil {
+				label = date.AddDate(0, 0, -(int(date.Weekday())+6)%7).Format("Jan 2")
+			}
+			if n := len(items); n > 0 && items[n-1].Name == label {
+				items[n-1].TotalSeconds += day.GrandTotal.TotalSeconds
+				continue
+			}
+		}
+		items = append(items, types.StatItem{Name: label, TotalSeconds: day.GrandTotal.TotalSeconds})
+	}
+
+	active := items[:0]
+	for _, item := range items {
+		if item.TotalSeconds >= Opts.MinSeconds {
+			active = append(active, item)
+		}
+	}
+	return title, active
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// daysFrom returns a day per seconds value, starting at start (YYYY-MM-DD).
+func daysFrom(start string, seconds ...float64) []types.DayData {
+	date, _ := time.Parse("2006-01-02", start)
+	days := make([]types.DayData, len(seconds))
+	for i, secs := range seconds {
+		d := date.AddDate(0, 0, i).Format("2006-01-02")
+		days[i].Range.Date = d
+		days[i].Range.Start = d + "T00:00:00Z"
+		days[i].Range.End = d + "T23:59:59Z"
+		days[i].GrandTotal.TotalSeconds = secs
+	}
+	return days
+}
+
+func TestProjectTrend(t *testing.T) {
+	defer func(min float64) { Opts.MinSeconds = min }(Opts.MinSeconds)
+
+	month := make([]float64, 31)
+	month[0], month[1], month[30] = 600, 30, 1200
+	// 2026-09-28 is a Monday: 36 days are 5 full weeks and the Monday of the 6th
+	weeks := make([]float64, 36)
+	for i := range weeks {
+		weeks[i] = 60
+	}
+	weeks[8], weeks[35] = 0, 3600
+	for i := 14; i < 21; i++ {
+		weeks[i] = 0 // the week of Oct 12 without activity
+	}
+
+	tests := []struct {
+		name      string
+		days      []types.DayData
+		min       float64
+		wantTitle string
+		want      []types.StatItem
+	}{
+		{"daily", daysFrom("2026-10-01", 600, 0, 1200), 60, "Daily", statItems("Oct 1", 600, "Oct 3", 1200)},
+		{"daily with --min 0", daysFrom("2026-10-01", 600, 0, 1200), 0, "Daily", statItems("Oct 1", 600, "Oct 2", 0, "Oct 3", 1200)},
+		{"31 days are still daily", daysFrom("2026-10-01", month...), 60, "Daily", statItems("Oct 1", 600, "Oct 31", 1200)},
+		{"weekly, by Monday", daysFrom("2026-09-28", weeks...), 60, "Weekly",
+			statItems("Sep 28", 420, "Oct 5", 360, "Oct 19", 420, "Oct 26", 420, "Nov 2", 3600)},
+		{"weekly, starting mid-week", daysFrom("2026-10-01", weeks...), 60, "Weekly",
+			// Oct 1 is a Thursday: its week starts on Sep 28
+			statItems("Sep 28", 240, "Oct 5", 360, "Oct 12", 180, "Oct 19", 240, "Oct 26", 420, "Nov 2", 3780)},
+		{"weekly with --min", daysFrom("2026-09-28", weeks...), 400, "Weekly",
+			statItems("Sep 28", 420, "Oct 19", 420, "Oct 26", 420, "Nov 2", 3600)},
+		{"nothing above --min", daysFrom("2026-10-01", 10, 20), 60, "Daily", []types.StatItem{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Opts.MinSeconds = tt.min
+			title, got := projectTrend(tt.days)
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			if len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("items = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	days := daysFrom("2026-10-01", 3600, 0, 7200)
+	days[0].Languages = statItems("Go", 3600)
+	days[2].Languages = statItems("Go", 5400, "Markdown", 1800)
+	days[2].Branches = statItems("main", 7200)
+	days[2].Entities = statItems("main.go", 5400, "readme.md", 1800)
+	got := projectFields(days, aggregateDays(days), 10800)
+	want := []Field{
+		{"Total Time", "3h 0m"},
+		{"Daily Avg", "1h 0m"},
+		{"Active Days", "2/3 days"},
+		{"Best Day", formatBestDay("2026-10-03") + " (2h 0m)"},
+		{"Top Language", "Go"},
+		{"Top Branch", "main"},
+		{"Top Editor", "None"},
+		{"Files", "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("projectFields() = %v, want %v", got, want)
+	}
+
+	// a single day has no averages
+	if got := projectFields(days[:1], aggregateDays(days[:1]), 3600); len(got) != 5 {
+		t.Errorf("projectFields() of a day = %v, want 5 fields", got)
+	}
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
@@ -15,6 +17,12 @@ const (
 	gapY     = 0
 )
 
+// out is where the card views are printed, swapped out by Capture.
+var out io.Writer = os.Stdout
+
+// warnOut is where Warnln prints, swapped out by CaptureText.
+var warnOut io.Writer = os.Stderr
+
 type CardConfig struct {
 	Title string
 	Lines []string
@@ -44,7 +52,9 @@ func processCardConfigs(configs []CardConfig) ([]string, int) {
 	for _, config := range configs {
 		rightPad := maxWidth - config.Width
 		cardLines, cardWidth := cardify(config.Lines, config.Title, config.Width, rightPad)
-		finalCardWidth = cardWidth
+		if cardWidth > 0 {
+			finalCardWidth = cardWidth
+		}
 		for range gapY {
 			allCards = append(allCards, strings.Repeat(" ", cardWidth))
 		}
@@ -62,58 +72,68 @@ func renderCardSection(section CardSection) {
 
 func render(p *DisplayPayload) {
 	fields, fieldsWidth := fieldsStr(p.Heading, p.Stats)
-	langLimit := len(fields)
-	langGraph, langWidth := graphStr(p.Languages, langLimit)
+	cards := rotateCards(selectedCards(), p.FirstCard)
 	shrink := getTerminalCols() < 96
 
-	if p.Full {
-		projectsLines, projectsWidth := graphStr(p.Projects, 0)
-		categoriesLines, categoriesWidth := graphStr(p.Categories, 0)
-		machinesLines, machinesWidth := graphStr(p.Machines, 0)
-		editorsLines, editorsWidth := graphStr(p.Editors, 0)
-		osLines, osWidth := graphStr(p.OperatingSystems, 0)
-		entitiesLines, entitiesWidth := graphStr(p.Entities, 5)
-		var fullSection CardSection
-		if !shrink {
-			fullSection = CardSection{
-				Left: []CardConfig{
-					{Title: "Languages", Lines: langGraph, Width: langWidth},
-					{Title: "Projects", Lines: projectsLines, Width: projectsWidth},
-					{Title: "Categories", Lines: categoriesLines, Width: categoriesWidth},
-					{Title: "Entities", Lines: entitiesLines, Width: entitiesWidth},
-				},
-				Right: []CardConfig{
-					{Title: "Stats", Lines: fields, Width: fieldsWidth},
-					{Title: "Editors", Lines: editorsLines, Width: editorsWidth},
-					{Title: "Operating Systems", Lines: osLines, Width: osWidth},
-					{Title: "Machines", Lines: machinesLines, Width: machinesWidth},
-				},
-			}
-		} else {
-			fullSection = CardSection{
-				Left: []CardConfig{
-					{Title: "Languages", Lines: langGraph, Width: langWidth},
-					{Title: "Stats", Lines: fields, Width: fieldsWidth},
-					{Title: "Projects", Lines: projectsLines, Width: projectsWidth},
-					{Title: "Categories", Lines: categoriesLines, Width: categoriesWidth},
-					{Title: "Editors", Lines: editorsLines, Width: editorsWidth},
-					{Title: "Entities", Lines: entitiesLines, Width: entitiesWidth},
-					{Title: "Operating Systems", Lines: osLines, Width: osWidth},
-					{Title: "Machines", Lines: machinesLines, Width: machinesWidth},
-				},
-				Right: []CardConfig{},
-			}
+	if Opts.Fetch {
+		renderFetch(p, fields, fieldsWidth)
+		if !p.Full {
+			return
 		}
-		renderCardSection(fullSection)
-	} else {
-		langGraphCard, langWidth := cardify(langGraph, "Languages", langWidth, 0)
+		// the stats are in the fetch layout already, the cards follow it
+		fmt.Fprintln(out)
+	} else if !p.Full {
+		// compact view: first card next to the stats, as long as the stats by default
+		first := cards[0]
+		graph, graphWidth := graphStr(cardItems(p, first.Name), compactLimit(first, len(fields)))
+		graphCard, graphWidth := cardify(graph, cardTitles[first.Name], graphWidth, 0)
 		if shrink {
-			printStrs(langGraphCard)
+			printStrs(graphCard)
 			printStrs(fields)
 		} else {
-			printLeftRight(langGraphCard, fields, 2, langWidth)
+			printLeftRight(graphCard, fields, 2, graphWidth)
+		}
+		return
+	}
+
+	statsCard := CardConfig{Title: "Stats", Lines: fields, Width: fieldsWidth}
+	var fullSection CardSection
+	if !shrink && !Opts.Fetch {
+		fullSection.Right = append(fullSection.Right, statsCard)
+	}
+	for i, spec := range cards {
+		lines, width := graphStr(cardItems(p, spec.Name), cardLimit(spec))
+		card := CardConfig{Title: cardTitles[spec.Name], Lines: lines, Width: width}
+		switch {
+		case shrink:
+			fullSection.Left = append(fullSection.Left, card)
+			if i == 0 && !Opts.Fetch {
+				fullSection.Left = append(fullSection.Left, statsCard)
+			}
+		case i%2 == 0:
+			fullSection.Left = append(fullSection.Left, card)
+		default:
+			fullSection.Right = append(fullSection.Right, card)
 		}
 	}
+	renderCardSection(fullSection)
+}
+
+// renderFetch prints the --fetch layout: the logo on the left, the stats fields and
+// the color blocks on the right, like neofetch. The logo goes on top when it doesn't fit.
+func renderFetch(p *DisplayPayload, fields []string, fieldsWidth int) {
+	logo, logoWidth := fetchLogoStr(p)
+	right := fields
+	if blocks := colorBlocksStr(); blocks != nil {
+		right = append(append(right, ""), blocks...)
+	}
+	if getTerminalCols() < logoWidth+3+max(fieldsWidth, 8*3) { // 8 color blocks
+		printStrs(logo)
+		fmt.Fprintln(out)
+		printStrs(right)
+		return
+	}
+	printLeftRight(logo, right, 3, logoWidth)
 }
 
 func formatRangeHeading(rangeStr string) string {
@@ -154,10 +174,8 @@ func fieldsStr(heading string, stats []Field) ([]string, int) {
 	headingSplit := strings.Split(heading, "(")
 	headingPadded := fmt.Sprintf("%-*s", maxWidth, heading)
 	headingLine := Clr.BoldBlue + headingPadded[:len(headingSplit[0])] + Clr.Reset
-	if len(headingSplit) > 1 {
-		remaining := headingPadded[len(headingSplit[0]):]
-		headingLine += Clr.Blue + remaining + Clr.Reset
-	}
+	// the rest is the "(...)" part, if any, and the padding
+	headingLine += Clr.Blue + headingPadded[len(headingSplit[0]):] + Clr.Reset
 	output = append(output, headingLine)
 
 	separatorLine := fmt.Sprintf("%-*s", maxWidth, strings.Repeat("-", len(heading)))
@@ -175,7 +193,7 @@ func fieldsStr(heading string, stats []Field) ([]string, int) {
 
 func printStrs(strs []string) {
 	for _, str := range strs {
-		fmt.Println(str)
+		fmt.Fprintln(out, str)
 	}
 }
 
@@ -184,10 +202,10 @@ func printLeftRight(left, right []string, spacing, leftWidth int) {
 
 	for i, line := range left {
 		if i >= len(right) {
-			fmt.Println(line)
+			fmt.Fprintln(out, line)
 			continue
 		}
-		fmt.Println(line + space + right[i])
+		fmt.Fprintln(out, line+space+right[i])
 	}
 	if len(left) == 0 {
 		spacing = 0
@@ -199,13 +217,19 @@ func printLeftRight(left, right []string, spacing, leftWidth int) {
 			pad = leftWidth + spacing
 		}
 		for i := len(left); i < len(right); i++ {
-			fmt.Println(strings.Repeat(" ", pad) + right[i])
+			fmt.Fprintln(out, strings.Repeat(" ", pad)+right[i])
 		}
 	}
 }
 
+// fixedCols overrides the terminal width when > 0 (used by Capture).
+var fixedCols int
+
 func getTerminalCols() int {
 	const fallback = 9999
+	if fixedCols > 0 {
+		return fixedCols
+	}
 	var sizeStr string
 	switch runtime.GOOS {
 	case "linux":
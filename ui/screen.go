@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// captureCols is the terminal width views are laid out for when captured:
+// wide enough for the two column --full layout and a year of heatmap.
+const captureCols = 120
+
+// style is the SGR state of a cell: an ANSI color code (31, 90, ...),
+// or a 24-bit color, and bold.
+type style struct {
+	fg        int
+	rgb       [3]uint8
+	truecolor bool
+	bold      bool
+}
+
+// cell is a terminal column. A wide rune (CJK, emoji) takes two: its own and
+// a continuation cell with r 0. Zero-width runes go into marks, after r.
+type cell struct {
+	r     rune
+	marks string
+	style style
+}
+
+// Screen is captured terminal output, one styled cell per terminal column.
+// It's what the image exporters (SVG, PNG) draw.
+type Screen struct {
+	lines [][]cell
+	cols  int
+}
+
+// Capture runs fn (e.g. a Display* call) with colors on and a fixed
+// terminal width, and returns what it printed instead of printing it.
+func Capture(fn func()) *Screen {
+	var buf bytes.Buffer
+	prevOut, prevClr, prevCols := out, Clr, fixedCols
+	out, Clr, fixedCols = &buf, defaultColors, captureCols
+	defer func() {
+		out, Clr, fixedCols = prevOut, prevClr, prevCols
+	}()
+
+	fn()
+
+	s := &Screen{}
+	text := strings.TrimRight(buf.String(), "\n")
+	if text == "" {
+		return s
+	}
+	for _, line := range strings.Split(text, "\n") {
+		cells := parseANSI(line)
+		s.lines = append(s.lines, cells)
+		s.cols = max(s.cols, len(cells))
+	}
+	return s
+}
+
+// CaptureText runs fn and returns what it printed as is, colors and terminal
+// width unchanged, including warnings. It's how --watch builds a frame.
+func CaptureText(fn func()) string {
+	var buf bytes.Buffer
+	prevOut, prevWarnOut := out, warnOut
+	out, warnOut = &buf, &buf
+	defer func() {
+		out, warnOut = prevOut, prevWarnOut
+	}()
+
+	fn()
+	return buf.String()
+}
+
+// Empty reports whether nothing was captured.
+func (s *Screen) Empty() bool {
+	return len(s.lines) == 0
+}
+
+// parseANSI splits a line into cells, applying SGR escape sequences (ESC[...m).
+func parseANSI(line string) []cell {
+	var cells []cell
+	st := style{}
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\x1b' && i+1 < len(runes) && runes[i+1] == '[' {
+			end := i + 2
+			for end < len(runes) && runes[end] != 'm' {
+				end++
+			}
+			if end < len(runes) {
+				st = applySGR(st, string(runes[i+2:end]))
+			}
+			i = end
+			continue
+		}
+		switch width := runeWidth(runes[i]); {
+		case width == 0 && runes[i] < ' ':
+			cells = append(cells, cell{r: runes[i], style: st}) // stray control characters keep their cell
+		case width == 0:
+			if n := len(cells); n > 0 {
+				cells[n-1].marks += string(runes[i])
+			}
+		case width == 2:
+			cells = append(cells, cell{r: runes[i], style: st}, cell{style: st})
+		default:
+			cells = append(cells, cell{r: runes[i], style: st})
+		}
+	}
+	return cells
+}
+
+func applySGR(st style, params string) style {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			st = style{}
+		case code == 1:
+			st.bold = true
+		case code == 38 && i+4 < len(codes) && codes[i+1] == "2":
+			for j := range 3 {
+				v, _ := strconv.Atoi(codes[i+2+j])
+				st.rgb[j] = uint8(v)
+			}
+			st.truecolor = true
+			st.fg = 0
+			i += 4
+		case code == 38 || code == 48:
+			// 256 colors, backgrounds and truncated sequences aren't drawn: skip their parameters
+			if i+1 < len(codes) {
+				switch codes[i+1] {
+				case "5":
+					i += 2
+				case "2":
+					i += 4
+				}
+			}
+		case (code >= 30 && code <= 37) || (code >= 90 && code <= 97):
+			st.fg = code
+			st.truecolor = false
+		case code == 39:
+			st.fg = 0
+			st.truecolor = false
+		}
+	}
+	return st
+}
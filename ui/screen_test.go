@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplySGR(t *testing.T) {
+	bold := style{bold: true}
+	tests := []struct {
+		name   string
+		st     style
+		params string
+		want   style
+	}{
+		{"reset", style{fg: 31, bold: true}, "0", style{}},
+		{"empty resets", style{fg: 31}, "", style{}},
+		{"bold", style{}, "1", bold},
+		{"color", bold, "32", style{fg: 32, bold: true}},
+		{"bright color", style{}, "97", style{fg: 97}},
+		{"bold and color", style{}, "1;34", style{fg: 34, bold: true}},
+		{"truecolor", style{fg: 31}, "38;2;128;128;128", style{rgb: [3]uint8{128, 128, 128}, truecolor: true}},
+		{"truecolor then bold", style{}, "38;2;0;255;0;1", style{rgb: [3]uint8{0, 255, 0}, truecolor: true, bold: true}},
+		{"color after truecolor", style{rgb: [3]uint8{1, 2, 3}, truecolor: true}, "33", style{fg: 33, rgb: [3]uint8{1, 2, 3}}},
+		{"default color", style{fg: 31, bold: true}, "39", bold},
+		{"truncated truecolor", style{fg: 31}, "38;2;1", style{fg: 31}},
+		{"256 colors", style{fg: 31}, "38;5;1", style{fg: 31}},
+		{"background", style{}, "48;2;0;0;1;32", style{fg: 32}},
+		{"unsupported", style{fg: 31}, "4", style{fg: 31}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applySGR(tt.st, tt.params); got != tt.want {
+				t.Errorf("applySGR(%+v, %q) = %+v, want %+v", tt.st, tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseANSI(t *testing.T) {
+	red, green := style{fg: 31}, style{fg: 32}
+	tests := []struct {
+		name string
+		line string
+		want []cell
+	}{
+		{"plain", "ab", []cell{{r: 'a', style: style{}}, {r: 'b', style: style{}}}},
+		{"colored", "\x1b[31ma\x1b[0mb", []cell{{r: 'a', style: red}, {r: 'b', style: style{}}}},
+		{"runes", "\x1b[32m█▆\x1b[0m", []cell{{r: '█', style: green}, {r: '▆', style: green}}},
+		{"consecutive escapes", "\x1b[1m\x1b[31mx", []cell{{r: 'x', style: style{fg: 31, bold: true}}}},
+		{"only escapes", "\x1b[31m\x1b[0m", nil},
+		{"unterminated escape", "a\x1b[31", []cell{{r: 'a', style: style{}}}},
+		{"wide runes", "\x1b[31m日本\x1b[0m🌱", []cell{{r: '日', style: red}, {style: red}, {r: '本', style: red}, {style: red}, {r: '🌱'}, {}}},
+		{"combining mark", "e\u0301x", []cell{{r: 'e', marks: "\u0301"}, {r: 'x'}}},
+		{"leading combining mark", "\u0301x", []cell{{r: 'x'}}},
+		{"lone escape", "a\x1b", []cell{{r: 'a', style: style{}}, {r: '\x1b', style: style{}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseANSI(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseANSI(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+const svgFont = "ui-monospace, SFMono-Regular, Menlo, Consolas, 'DejaVu Sans Mono', monospace"
+
+// 14px monospace: 0.6em advance, 1.3 line height
+var svgMetrics = metrics{cellW: 8.4, cellH: 18.2, padding: 16}
+
+type svgCanvas struct {
+	sb strings.Builder
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, color string) {
+	fmt.Fprintf(&c.sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, w, h, color)
+}
+
+func (c *svgCanvas) text(x, y float64, s string, color string, bold bool) {
+	weight := ""
+	if bold {
+		weight = ` font-weight="bold"`
+	}
+	// textLength pins the run to the fixed cell width whatever font the viewer picks,
+	// two cells for wide characters like in the terminal
+	n := displayWidth(s)
+	fmt.Fprintf(&c.sb, `<text x="%.1f" y="%.1f" fill="%s"%s textLength="%.1f" lengthAdjust="spacingAndGlyphs">%s</text>`+"\n",
+		x, y+svgMetrics.cellH*0.75, color, weight, float64(n)*svgMetrics.cellW, html.EscapeString(s))
+}
+
+func writeSVGDocument(w io.Writer, width, height float64, body string) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="%s" font-size="14" xml:space="preserve">
+<title>wakafetch</title>
+%s</svg>
+`, width, height, width, height, svgFont, body)
+	return err
+}
+
+// WriteSVG writes the captured screen as a standalone SVG.
+func (s *Screen) WriteSVG(w io.Writer, t Theme) error {
+	cv := &svgCanvas{}
+	drawScreen(cv, s, t, svgMetrics)
+	width, height := svgMetrics.size(s)
+	return writeSVGDocument(w, width, height, cv.sb.String())
+}
+
+// WriteHeatmapSVG writes days as a GitHub style calendar: a row per weekday, a column per week.
+func WriteHeatmapSVG(w io.Writer, days []types.DayData, heading string, t Theme) error {
+	const (
+		square = 11.0
+		gap    = 3.0
+		step   = square + gap
+		left   = 40.0 // weekday labels
+		top    = 56.0 // heading + month labels
+		pad    = 16.0
+	)
+	if len(days) == 0 {
+		return fmt.Errorf("no daily data available")
+	}
+	start, err1 := time.Parse("2006-01-02", DatePart(days[0].Range.Start))
+	end, err2 := time.Parse("2006-01-02", DatePart(days[len(days)-1].Range.Start))
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return fmt.Errorf("invalid dates in daily data")
+	}
+	seconds := make(map[string]float64, len(days))
+	for _, day := range days {
+		seconds[DatePart(day.Range.Start)] = day.GrandTotal.TotalSeconds
+	}
+
+	// weeks start on Monday
+	first := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	numWeeks := int(end.Sub(first).Hours()/24)/7 + 1
+
+	cv := &svgCanvas{}
+	width := max(left+float64(numWeeks)*step+pad, 300)
+	height := top + 7*step + 40
+	cv.rect(0, 0, width, height, t.Background)
+	fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-weight="bold">%s</text>`+"\n", pad, 24.0, t.Yellow, html.EscapeString(heading))
+
+	for weekday, label := range []string{"Mon", "", "Wed", "", "Fri", "", ""} {
+		if label != "" {
+			fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-size="10">%s</text>`+"\n", pad, top+float64(weekday)*step+square-1, t.Foreground, label)
+		}
+	}
+
+	lastMonth := time.Month(0)
+	total := 0.0
+	for week := range numWeeks {
+		weekStart := first.AddDate(0, 0, 7*week)
+		x := left + float64(week)*step
+		if weekStart.Month() != lastMonth && !weekStart.AddDate(0, 0, 6).Before(start) {
+			fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-size="10">%s</text>`+"\n", x, top-6, t.Foreground, weekStart.Format("Jan"))
+			lastMonth = weekStart.Month()
+		}
+		for weekday := range 7 {
+			d := weekStart.AddDate(0, 0, weekday)
+			if d.Before(start) || d.After(end) {
+				continue
+			}
+			secs := seconds[d.Format("2006-01-02")]
+			total += secs
+			fmt.Fprintf(&cv.sb, `<rect x="%.1f" y="%.1f" width="%.0f" height="%.0f" rx="2" fill="%s"><title>%s: %s</title></rect>`+"\n",
+				x, top+float64(weekday)*step, square, square, t.Heatmap[heatmapLevel(secs)], d.Format("Jan 2, 2006"), timeFmt(secs))
+		}
+	}
+
+	// footer: total on the left, legend on the right
+	footerY := top + 7*step + 20
+	fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-size="11">%s total</text>`+"\n", left, footerY, t.Foreground, timeFmt(total))
+	legendX := width - pad - float64(numHeatmapLevels)*step - 60
+	fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-size="11">Less</text>`+"\n", legendX, footerY, t.Foreground)
+	for level, color := range t.Heatmap {
+		fmt.Fprintf(&cv.sb, `<rect x="%.1f" y="%.1f" width="%.0f" height="%.0f" rx="2" fill="%s"/>`+"\n", legendX+30+float64(level)*step, footerY-square+1, square, square, color)
+	}
+	fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" fill="%s" font-size="11">More</text>`+"\n", legendX+34+float64(numHeatmapLevels)*step, footerY, t.Foreground)
+
+	return writeSVGDocument(w, width, height, cv.sb.String())
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got to testdata/name, or rewrites it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./ui -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file (run go test ./ui -update if the change is intended):\n%s", name, got)
+	}
+}
+
+func goldenPayload() *DisplayPayload {
+	return &DisplayPayload{
+		Heading: "Last 7 Days",
+		Stats: []Field{
+			{"Total Time", "12h 30m"},
+			{"Daily Avg", "1h 47m"},
+			{"Top Project", "wakafetch"},
+		},
+		Languages: statItems("Go", 30000, "Markdown", 9000, "YAML", 4000, "Shell", 30),
+		Editors:   statItems("Neovim", 36000, "VS Code", 7030),
+		Projects:  statItems("wakafetch", 40000, "dotfiles", 3030),
+		Full:      true,
+	}
+}
+
+func TestWriteSVGGolden(t *testing.T) {
+	defer func(o Options) { Opts = o }(Opts)
+	Opts = Options{MinSeconds: 60, Cards: []CardSpec{{Name: "languages"}, {Name: "editors"}, {Name: "projects"}}}
+
+	screen := Capture(func() { render(goldenPayload()) })
+	var buf bytes.Buffer
+	if err := screen.WriteSVG(&buf, Themes["dark"]); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "stats.svg", buf.Bytes())
+}
+
+func TestWriteHeatmapSVGGolden(t *testing.T) {
+	days := daysOf(0, 1800, 3600, 7200, 0, 14400, 600, 5400, 0, 10800)
+	var buf bytes.Buffer
+	if err := WriteHeatmapSVG(&buf, days, "Oct 1 - Oct 10", Themes["dark"]); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "heatmap.svg", buf.Bytes())
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// table is the content of a card with rows under column titles.
+type table struct {
+	columns []string
+	rows    [][]string
+	// colors is the color of each row, "" (or missing) for the default.
+	colors []string
+	// fit is the column shortened with shorten to what's left of the terminal
+	// (at least 20 columns), -1 for none.
+	fit     int
+	shorten func(s string, width int) string
+}
+
+// lines lays the table out, returning its lines and width.
+func (t table) lines() ([]string, int) {
+	widths := make([]int, len(t.columns))
+	for i, title := range t.columns {
+		widths[i] = len(title)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	if t.fit >= 0 {
+		others := 4 + 3*(len(widths)-1) // card borders and separators
+		for i, w := range widths {
+			if i != t.fit {
+				others += w
+			}
+		}
+		if cols := getTerminalCols(); cols > 0 {
+			widths[t.fit] = min(widths[t.fit], max(20, cols-others))
+		}
+	}
+
+	pad := func(row []string, color string) string {
+		parts := make([]string, len(row))
+		for i, cell := range row {
+			if i == t.fit {
+				cell = t.shorten(cell, widths[i])
+			}
+			parts[i] = color + fmt.Sprintf("%-*s", widths[i], cell) + Clr.Reset
+		}
+		return strings.Join(parts, " │ ")
+	}
+	separator := make([]string, len(widths))
+	for i, w := range widths {
+		separator[i] = strings.Repeat("─", w)
+	}
+
+	lines := []string{pad(t.columns, Clr.Blue), strings.Join(separator, "─┼─")}
+	for i, row := range t.rows {
+		color := ""
+		if i < len(t.colors) {
+			color = t.colors[i]
+		}
+		lines = append(lines, pad(row, color))
+	}
+	total := 3 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	return lines, total
+}
+
+// shortenPath keeps the end of a path that's longer than width: ".../pkg/file.go".
+func shortenPath(path string, width int) string {
+	if len(path) <= width {
+		return path
+	}
+	return "..." + path[len(path)-width+3:]
+}
+
+// shortenText keeps the start of a text that's longer than width: "Go, Python, ...".
+func shortenText(text string, width int) string {
+	if len(text) <= width {
+		return text
+	}
+	return text[:width-3] + "..."
+}
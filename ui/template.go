@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are available in --format templates, e.g.
+//
+//	{{duration .TotalSeconds}}          -> "4h 17m"
+//	{{hours .TotalSeconds}}             -> "4.3"
+//	{{percent 25.5}}                    -> "25.5%"
+//	{{percent .TotalSeconds 14400}}     -> "107.1%" (part, whole)
+//	{{bar .Seconds $.TotalSeconds 20}}  -> "▆▆▆▆▆▆▆             "
+//	{{range top 3 .Languages}}...{{end}}
+var templateFuncs = template.FuncMap{
+	"duration": func(seconds any) string {
+		return timeFmt(toFloat(seconds))
+	},
+	"hours": func(seconds any) string {
+		return fmt.Sprintf("%.1f", toFloat(seconds)/3600)
+	},
+	"percent": func(values ...any) (string, error) {
+		switch len(values) {
+		case 1:
+			return fmt.Sprintf("%.1f%%", toFloat(values[0])), nil
+		case 2:
+			whole := toFloat(values[1])
+			if whole == 0 {
+				return "0.0%", nil
+			}
+			return fmt.Sprintf("%.1f%%", toFloat(values[0])/whole*100), nil
+		}
+		return "", fmt.Errorf("percent takes a percentage or a part and a whole")
+	},
+	"bar": func(value, maxValue any, width int) string {
+		return textBar(toFloat(value), toFloat(maxValue), width, " ")
+	},
+	"top": func(n int, items []Item) []Item {
+		if n >= 0 && n < len(items) {
+			return items[:n]
+		}
+		return items
+	},
+}
+
+// ParseTemplate parses a --format template with wakafetch's helper funcs.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("format").Funcs(templateFuncs).Parse(text)
+}
+
+// RenderTemplate executes tmpl against v, ending the output with a newline.
+func RenderTemplate(w io.Writer, tmpl *template.Template, v *View) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, v); err != nil {
+		return err
+	}
+	out := sb.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// textBar draws a bar of width cells, filled in proportion to value/maxValue
+// (at least one cell for any non-zero value) and padded with empty.
+func textBar(value, maxValue float64, width int, empty string) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := 0
+	if maxValue > 0 {
+		filled = int(value / maxValue * float64(width))
+	}
+	if filled < 1 && value > 0 {
+		filled = 1
+	}
+	filled = min(filled, width)
+	return strings.Repeat(barChar, filled) + strings.Repeat(empty, width-filled)
+}
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case int32:
+		return float64(n)
+	}
+	return 0
+}
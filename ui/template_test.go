@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	v := &View{TotalSeconds: 15420, Breakdown: Breakdown{Languages: []Item{
+		{Name: "Go", Seconds: 10800, Percent: 70},
+		{Name: "Rust", Seconds: 3600, Percent: 23.35},
+		{Name: "C", Seconds: 1020, Percent: 6.65},
+	}}}
+	tests := []struct {
+		name, template, want string
+	}{
+		{"duration", `{{duration .TotalSeconds}}`, "4h 17m"},
+		{"duration under an hour", `{{duration 725}}`, "12m 5s"},
+		{"duration of a non-number", `{{duration "x"}}`, "0m 0s"},
+		{"hours", `{{hours .TotalSeconds}}`, "4.3"},
+		{"hours of an int", `{{hours 5400}}`, "1.5"},
+		{"percent", `{{percent 25.5}}`, "25.5%"},
+		{"percent of a whole", `{{percent .TotalSeconds 14400}}`, "107.1%"},
+		{"percent of zero", `{{percent 10 0}}`, "0.0%"},
+		{"bar", `[{{bar 5 10 4}}]`, "[▆▆  ]"},
+		{"full bar", `[{{bar 10 10 4}}]`, "[▆▆▆▆]"},
+		{"bar over max", `[{{bar 20 10 4}}]`, "[▆▆▆▆]"},
+		{"tiny bar", `[{{bar 0.1 10 4}}]`, "[▆   ]"},
+		{"empty bar", `[{{bar 0 10 4}}]`, "[    ]"},
+		{"bar of zero max", `[{{bar 5 0 3}}]`, "[▆  ]"},
+		{"bar of no width", `[{{bar 5 10 0}}]`, "[]"},
+		{"top", `{{range top 2 .Languages}}{{.Name}} {{end}}`, "Go Rust "},
+		{"top more than all", `{{range top 5 .Languages}}{{.Name}} {{end}}`, "Go Rust C "},
+		{"top 0", `{{range top 0 .Languages}}{{.Name}}{{else}}none{{end}}`, "none"},
+		{"top negative", `{{len (top -1 .Languages)}}`, "3"},
+		{"together", `{{range top 1 .Languages}}{{.Name}} {{duration .Seconds}} {{percent .Percent}} {{bar .Seconds $.TotalSeconds 5}}{{end}}`, "Go 3h 0m 70.0% ▆▆▆  "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sb strings.Builder
+			if err := RenderTemplate(&sb, tmpl, v); err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSuffix(sb.String(), "\n"); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+
+	tmpl, err := ParseTemplate(`{{percent 1 2 3}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RenderTemplate(&strings.Builder{}, tmpl, v); err == nil || !strings.Contains(err.Error(), "percent takes") {
+		t.Errorf("percent with 3 arguments: error = %v", err)
+	}
+}
+
+func TestTopName(t *testing.T) {
+	tests := []struct {
+		names       []string
+		skipUnknown bool
+		want        string
+	}{
+		{nil, false, ""},
+		{[]string{"Go", "Rust"}, false, "Go"},
+		{[]string{"unknown", "app"}, false, "unknown"},
+		{[]string{"unknown", "app"}, true, "app"},
+		{[]string{"unknown"}, true, "unknown"},
+	}
+	for _, tt := range tests {
+		items := make([]Item, len(tt.names))
+		for i, name := range tt.names {
+			items[i].Name = name
+		}
+		if got := topName(items, tt.skipUnknown); got != tt.want {
+			t.Errorf("topName(%v, %v) = %q, want %q", tt.names, tt.skipUnknown, got, tt.want)
+		}
+	}
+}
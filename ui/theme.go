@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Theme is the palette used by the image exporters, hex colors ("#rrggbb").
+// It maps the 16 ANSI colors (Gray is bright black, Clr.Gray), the default
+// foreground and background, the card borders, and the heatmap's intensity levels.
+type Theme struct {
+	Background    string
+	Foreground    string
+	Border        string // Clr.MidGray, card borders
+	Black         string
+	Red           string
+	Green         string
+	Yellow        string
+	Blue          string
+	Magenta       string
+	Cyan          string
+	White         string
+	Gray          string
+	BrightRed     string
+	BrightGreen   string
+	BrightYellow  string
+	BrightBlue    string
+	BrightMagenta string
+	BrightCyan    string
+	BrightWhite   string
+	Heatmap       [numHeatmapLevels]string
+}
+
+// Themes are the built-in themes, selected with --theme.
+// "dark" is VS Code's dark terminal palette, with the terminal's heatmap greens.
+var Themes = map[string]Theme{
+	"dark": {
+		Background:    "#1e1e1e",
+		Foreground:    "#d4d4d4",
+		Border:        "#808080",
+		Black:         "#000000",
+		Red:           "#cd3131",
+		Green:         "#0dbc79",
+		Yellow:        "#e5e510",
+		Blue:          "#2472c8",
+		Magenta:       "#bc3fbc",
+		Cyan:          "#11a8cd",
+		White:         "#e5e5e5",
+		Gray:          "#666666",
+		BrightRed:     "#f14c4c",
+		BrightGreen:   "#23d18b",
+		BrightYellow:  "#f5f543",
+		BrightBlue:    "#3b8eea",
+		BrightMagenta: "#d670d6",
+		BrightCyan:    "#29b8db",
+		BrightWhite:   "#ffffff",
+		Heatmap:       terminalHeatmap(),
+	},
+	"light": {
+		Background:    "#ffffff",
+		Foreground:    "#24292f",
+		Border:        "#8c959f",
+		Black:         "#24292f",
+		Red:           "#cf222e",
+		Green:         "#1a7f37",
+		Yellow:        "#9a6700",
+		Blue:          "#0969da",
+		Magenta:       "#8250df",
+		Cyan:          "#1b7c83",
+		White:         "#6e7781",
+		Gray:          "#d0d7de",
+		BrightRed:     "#a40e26",
+		BrightGreen:   "#2da44e",
+		BrightYellow:  "#bf8700",
+		BrightBlue:    "#218bff",
+		BrightMagenta: "#a475f9",
+		BrightCyan:    "#3192aa",
+		BrightWhite:   "#8c959f",
+		Heatmap:       [numHeatmapLevels]string{"#ebedf0", "#aceebb", "#6fdd8b", "#4ac26b", "#2da44e", "#116329"},
+	},
+	"github-dark": {
+		Background:    "#0d1117",
+		Foreground:    "#c9d1d9",
+		Border:        "#30363d",
+		Black:         "#484f58",
+		Red:           "#f85149",
+		Green:         "#3fb950",
+		Yellow:        "#d29922",
+		Blue:          "#58a6ff",
+		Magenta:       "#bc8cff",
+		Cyan:          "#39c5cf",
+		White:         "#b1bac4",
+		Gray:          "#30363d",
+		BrightRed:     "#ffa198",
+		BrightGreen:   "#56d364",
+		BrightYellow:  "#e3b341",
+		BrightBlue:    "#79c0ff",
+		BrightMagenta: "#d2a8ff",
+		BrightCyan:    "#56d4dd",
+		BrightWhite:   "#f0f6fc",
+		Heatmap:       [numHeatmapLevels]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353", "#7ee787"},
+	},
+}
+
+// CurrentTheme returns the theme selected in Opts (dark by default).
+func CurrentTheme() Theme {
+	if t, ok := Themes[Opts.Theme]; ok {
+		return t
+	}
+	return Themes["dark"]
+}
+
+// ValidateTheme checks that name is a built-in theme.
+func ValidateTheme(name string) error {
+	if _, ok := Themes[name]; ok {
+		return nil
+	}
+	names := make([]string, 0, len(Themes))
+	for n := range Themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown theme '%s', must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// terminalHeatmap is the heatmap's terminal colors: rgb(0, green, 0).
+func terminalHeatmap() [numHeatmapLevels]string {
+	var levels [numHeatmapLevels]string
+	for i, g := range greenLevels {
+		levels[i] = fmt.Sprintf("#00%02x00", g)
+	}
+	return levels
+}
+
+// color resolves a cell's style to a theme color.
+func (t Theme) color(st style) string {
+	if st.truecolor {
+		if st.rgb == [3]uint8{128, 128, 128} {
+			return t.Border
+		}
+		if st.rgb[0] == 0 && st.rgb[2] == 0 {
+			for level, g := range greenLevels {
+				if int(st.rgb[1]) == g {
+					return t.Heatmap[level]
+				}
+			}
+		}
+		return fmt.Sprintf("#%02x%02x%02x", st.rgb[0], st.rgb[1], st.rgb[2])
+	}
+	if st.fg >= 30 && st.fg <= 37 {
+		return t.ansi()[st.fg-30]
+	}
+	if st.fg >= 90 && st.fg <= 97 {
+		return t.ansi()[st.fg-90+8]
+	}
+	return t.Foreground
+}
+
+// ansi lists the theme's 16 ANSI colors in terminal order: 30-37, then 90-97.
+func (t Theme) ansi() [16]string {
+	return [16]string{
+		t.Black, t.Red, t.Green, t.Yellow, t.Blue, t.Magenta, t.Cyan, t.White,
+		t.Gray, t.BrightRed, t.BrightGreen, t.BrightYellow, t.BrightBlue, t.BrightMagenta, t.BrightCyan, t.BrightWhite,
+	}
+}
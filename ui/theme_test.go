@@ -0,0 +1,24 @@
+package ui
+
+import "testing"
+
+func TestThemeColorMapsANSIColors(t *testing.T) {
+	for name, theme := range Themes {
+		seen := map[string]int{}
+		for _, codes := range [][2]int{{30, 37}, {90, 97}} {
+			for code := codes[0]; code <= codes[1]; code++ {
+				color := theme.color(style{fg: code})
+				if color == "" {
+					t.Errorf("%s: no color for SGR %d", name, code)
+				}
+				if prev, ok := seen[color]; ok {
+					t.Errorf("%s: SGR %d and %d are both %s", name, prev, code, color)
+				}
+				seen[color] = code
+			}
+		}
+		if got := theme.color(style{}); got != theme.Foreground {
+			t.Errorf("%s: default color = %s, want the foreground %s", name, got, theme.Foreground)
+		}
+	}
+}
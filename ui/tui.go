@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// The views of `wakafetch tui`, built from the same pieces as the card views,
+// with the selected day (YYYY-MM-DD) marked.
+
+// DailyDates returns the dates of the daily table's rows, in table order (newest first).
+func DailyDates(days []types.DayData) []string {
+	var dates []string
+	for _, day := range days {
+		if day.GrandTotal.TotalSeconds >= Opts.MinSeconds {
+			dates = append(dates, DatePart(day.Range.Start))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+	return dates
+}
+
+// HeatmapHeight returns how many rows the heatmap of days wraps into, so
+// moving a column left or right is moving that many days.
+func HeatmapHeight(days []types.DayData) int {
+	if len(days) == 0 {
+		return 1
+	}
+	start, err1 := time.Parse("2006-01-02", DatePart(days[0].Range.Start))
+	end, err2 := time.Parse("2006-01-02", DatePart(days[len(days)-1].Range.Start))
+	if err1 != nil || err2 != nil {
+		return 1
+	}
+	return heatmapHeight(int(end.Sub(start).Hours()/24) + 1)
+}
+
+// DisplayDailySelected prints the daily breakdown table with a marker on the selected day's row.
+func DisplayDailySelected(days []types.DayData, heading string, selected string) {
+	table, width := dailyBreakdownStr(days)
+	if len(table) <= 2 {
+		Warnln("No daily data available")
+		return
+	}
+	dates := DailyDates(days)
+	for i := range table {
+		switch {
+		case i < 2: // header and separator
+			table[i] = "  " + table[i]
+		case dates[i-2] == selected:
+			table[i] = Clr.Bold + Clr.Yellow + "▶ " + Clr.Reset + table[i]
+		default:
+			table[i] = "  " + table[i]
+		}
+	}
+	card, _ := cardify(table, heading, width+2, 0)
+	printStrs(card)
+}
+
+// DisplayHeatmapSelected prints the heatmap with the selected day marked, and that day's details below it.
+func DisplayHeatmapSelected(days []types.DayData, heading string, selected string) {
+	strs, width := heatmapWithSelection(days, selected)
+	if len(strs) == 0 {
+		Warnln("No heatmap data available")
+		return
+	}
+	card, _ := cardify(strs, heading, width, 0)
+	printStrs(card)
+
+	for _, day := range days {
+		if DatePart(day.Range.Start) != selected {
+			continue
+		}
+		fields := []Field{
+			{"Total Time", timeFmt(day.GrandTotal.TotalSeconds)},
+			{"Top Language", topItemName(day.Languages, false)},
+			{"Top Project", topItemName(day.Projects, true)},
+			{"Top Editor", topItemName(day.Editors, false)},
+		}
+		lines, fieldsWidth := fieldsStr(formatBestDay(selected), fields)
+		details, _ := cardify(lines, "Selected day", fieldsWidth, 0)
+		printStrs(details)
+	}
+}
+
+// DisplayOverview is DisplaySummary with the cards starting at the card-th selected
+// one, so the compact view shows it.
+func DisplayOverview(data *types.SummaryResponse, full bool, rangeStr string, card int) {
+	if data == nil || len(data.Data) == 0 {
+		Warnln("No data available for the selected period: '%s'", rangeStr)
+		return
+	}
+	p := NewSummaryPayload(data, full, rangeStr)
+	p.FirstCard = card
+	render(p)
+}
+
+// DisplayDay prints one day like DisplayOverview prints a range.
+func DisplayDay(day types.DayData, full bool, card int) {
+	data := &types.SummaryResponse{Data: []types.DayData{day}, Start: day.Range.Start, End: day.Range.End}
+	data.CumulativeTotal.Seconds = day.GrandTotal.TotalSeconds
+	data.DailyAverage.Seconds = day.GrandTotal.TotalSeconds
+	data.DailyAverage.DaysIncludingHolidays = 1
+	if day.GrandTotal.TotalSeconds > 0 {
+		data.DailyAverage.DaysMinusHolidays = 1
+	}
+	weekday := day.Range.Start
+	if t, err := time.Parse("2006-01-02", DatePart(day.Range.Start)); err == nil {
+		weekday = t.Weekday().String()
+	}
+	DisplayOverview(data, full, weekday, card)
+}
+
+// CardCount is the number of selected cards, which DisplayOverview's card rotates through.
+func CardCount() int {
+	return len(selectedCards())
+}
+
+// CurrentCard is the title of the card the compact view shows when starting at card.
+func CurrentCard(card int) string {
+	cards := selectedCards()
+	return cardTitles[cards[card%len(cards)].Name]
+}
+
+// TUIHelp formats key bindings ("key", "action", ...) as a dim footer line.
+func TUIHelp(bindings ...string) string {
+	parts := make([]string, 0, len(bindings)/2)
+	for i := 0; i+1 < len(bindings); i += 2 {
+		parts = append(parts, fmt.Sprintf("%s%s%s %s", Clr.Bold, bindings[i], Clr.Reset+Clr.MidGray, bindings[i+1]))
+	}
+	return Clr.MidGray + strings.Join(parts, " · ") + Clr.Reset
+}
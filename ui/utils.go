@@ -85,6 +85,11 @@ func timeFmt(seconds float64) string {
 	return fmt.Sprintf("%dh %dm", hours, minutes)
 }
 
+// FormatDuration formats seconds the way the cards do ("4h 17m", "12m 5s").
+func FormatDuration(seconds float64) string {
+	return timeFmt(seconds)
+}
+
 func timeFmtPad(seconds, maxSeconds float64) string {
 	sec := int(seconds)
 	pad := 2
@@ -118,5 +123,5 @@ func Errorln(format string, args ...any) {
 }
 
 func Warnln(format string, args ...any) {
-	fmt.Fprintf(os.Stderr, Clr.Yellow+format+Clr.Reset+"\n", args...)
+	fmt.Fprintf(warnOut, Clr.Yellow+format+Clr.Reset+"\n", args...)
 }
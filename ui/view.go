@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+// View is the data model exposed to --format templates.
+// Field names are part of the template interface: add new ones, don't rename or remove.
+//
+// Durations are available both raw (…Seconds, float64) and human readable ("4h 17m").
+// Fields marked "summary only" are zero unless the data came from /summaries
+// (--days, --daily, --heatmap, a year range, or on WakaTime today/yesterday).
+type View struct {
+	Heading string // "Last 7 days (Oct 13 to Oct 19)"
+	Range   string // "Last 7 days"
+	Start   string // first day, YYYY-MM-DD
+	End     string // last day, YYYY-MM-DD
+
+	TotalSeconds        float64
+	Total               string
+	DailyAverageSeconds float64
+	DailyAverage        string
+
+	Days          int  // days in the range
+	ActiveDays    *int // days with any activity (summary, or stats on WakaTime; nil otherwise)
+	BestDay       *Day // busiest day (summary, or stats on WakaTime: without breakdowns)
+	Streak        int  // consecutive active days up to the end of the range (summary only)
+	LongestStreak int  // longest run of active days in the range (summary only)
+
+	TopLanguage string // "" when there's no data
+	TopProject  string // skips "unknown"
+	TopEditor   string
+	TopOS       string
+	TopCategory string
+	TopMachine  string
+
+	Breakdown
+
+	Daily []Day // oldest first (summary only)
+}
+
+// Breakdown holds the per-dimension items, sorted by time, descending.
+// Stats data has no Entities, and only WakaTime's has Dependencies.
+type Breakdown struct {
+	Languages        []Item
+	Projects         []Item
+	Editors          []Item
+	OperatingSystems []Item
+	Categories       []Item
+	Machines         []Item
+	Branches         []Item
+	Dependencies     []Item
+	Entities         []Item
+}
+
+// Item is one entry of a dimension (a language, a project, ...).
+type Item struct {
+	Name    string
+	Seconds float64
+	Time    string  // "1h 5m"
+	Percent float64 // share of the dimension's total, 0-100
+}
+
+// Day is one day of summary data.
+type Day struct {
+	Date        string // YYYY-MM-DD
+	Seconds     float64
+	Time        string
+	TopLanguage string
+	TopProject  string
+	Breakdown
+}
+
+// NewStatsView builds the template view from a /stats response.
+func NewStatsView(data *types.StatsResponse, rangeStr string) *View {
+	stats := data.Data
+	heading := formatRangeHeading(rangeStr)
+	if rangeStr != "all_time" {
+		heading += " (" + formatDateRange(stats.Start, stats.End) + ")"
+	}
+	p := &DisplayPayload{
+		Languages:        stats.Languages,
+		Projects:         stats.Projects,
+		Editors:          stats.Editors,
+		OperatingSystems: stats.OperatingSystems,
+		Categories:       stats.Categories,
+		Machines:         stats.Machines,
+		Branches:         stats.Branches,
+		Dependencies:     stats.Dependencies,
+	}
+	v := &View{
+		Heading:             heading,
+		Range:               formatRangeHeading(rangeStr),
+		Start:               DatePart(stats.Start),
+		End:                 DatePart(stats.End),
+		TotalSeconds:        stats.TotalSeconds,
+		Total:               timeFmt(stats.TotalSeconds),
+		DailyAverageSeconds: stats.DailyAverage,
+		DailyAverage:        timeFmt(stats.DailyAverage),
+		Days:                stats.DaysIncludingHolidays,
+		ActiveDays:          stats.DaysMinusHolidays,
+		Breakdown:           newBreakdown(p),
+	}
+	if best := stats.BestDay; best != nil && best.TotalSeconds > 0 {
+		v.BestDay = &Day{Date: DatePart(best.Date), Seconds: best.TotalSeconds, Time: timeFmt(best.TotalSeconds)}
+	}
+	v.setTopItems()
+	return v
+}
+
+// NewSummaryView builds the template view from a /summaries response.
+func NewSummaryView(data *types.SummaryResponse, rangeStr string) *View {
+	days := make([]types.DayData, len(data.Data))
+	copy(days, data.Data)
+	sort.SliceStable(days, func(i, j int) bool {
+		return DatePart(days[i].Range.Start) < DatePart(days[j].Range.Start)
+	})
+
+	v := &View{
+		Heading:             formatRangeHeading(rangeStr) + " (" + formatDateRange(data.Start, data.End) + ")",
+		Range:               formatRangeHeading(rangeStr),
+		Start:               DatePart(data.Start),
+		End:                 DatePart(data.End),
+		TotalSeconds:        data.CumulativeTotal.Seconds,
+		Total:               timeFmt(data.CumulativeTotal.Seconds),
+		DailyAverageSeconds: data.DailyAverage.Seconds,
+		DailyAverage:        timeFmt(data.DailyAverage.Seconds),
+		Days:                len(days),
+		Breakdown:           newBreakdown(aggregateDays(days)),
+	}
+	v.Streak, v.LongestStreak = streaks(days)
+	v.ActiveDays = new(int)
+
+	for _, day := range days {
+		d := newDay(day)
+		if d.Seconds > 0 {
+			*v.ActiveDays++
+		}
+		if v.BestDay == nil || d.Seconds > v.BestDay.Seconds {
+			best := d
+			v.BestDay = &best
+		}
+		v.Daily = append(v.Daily, d)
+	}
+	if v.BestDay != nil && v.BestDay.Seconds == 0 {
+		v.BestDay = nil
+	}
+	v.setTopItems()
+	return v
+}
+
+func newDay(day types.DayData) Day {
+	b := newBreakdown(&DisplayPayload{
+		Languages:        day.Languages,
+		Projects:         day.Projects,
+		Editors:          day.Editors,
+		OperatingSystems: day.OperatingSystems,
+		Categories:       day.Categories,
+		Machines:         day.Machines,
+		Branches:         day.Branches,
+		Dependencies:     day.Dependencies,
+		Entities:         day.Entities,
+	})
+	date := day.Range.Date
+	if date == "" {
+		date = DatePart(day.Range.Start)
+	}
+	return Day{
+		Date:        date,
+		Seconds:     day.GrandTotal.TotalSeconds,
+		Time:        timeFmt(day.GrandTotal.TotalSeconds),
+		TopLanguage: topName(b.Languages, false),
+		TopProject:  topName(b.Projects, true),
+		Breakdown:   b,
+	}
+}
+
+func (v *View) setTopItems() {
+	v.TopLanguage = topName(v.Languages, false)
+	v.TopProject = topName(v.Projects, true)
+	v.TopEditor = topName(v.Editors, false)
+	v.TopOS = topName(v.OperatingSystems, false)
+	v.TopCategory = topName(v.Categories, false)
+	v.TopMachine = topName(v.Machines, false)
+}
+
+func newBreakdown(p *DisplayPayload) Breakdown {
+	return Breakdown{
+		Languages:        newItems(p.Languages),
+		Projects:         newItems(p.Projects),
+		Editors:          newItems(p.Editors),
+		OperatingSystems: newItems(p.OperatingSystems),
+		Categories:       newItems(p.Categories),
+		Machines:         newItems(p.Machines),
+		Branches:         newItems(p.Branches),
+		Dependencies:     newItems(p.Dependencies),
+		Entities:         newItems(p.Entities),
+	}
+}
+
+func newItems(stats []types.StatItem) []Item {
+	if len(stats) == 0 {
+		return nil
+	}
+	total := totalSeconds(stats)
+	items := make([]Item, 0, len(stats))
+	for _, s := range stats {
+		item := Item{Name: s.Name, Seconds: s.TotalSeconds, Time: timeFmt(s.TotalSeconds)}
+		if total > 0 {
+			item.Percent = s.TotalSeconds / total * 100
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Seconds > items[j].Seconds
+	})
+	return items
+}
+
+// topName is topItemName for a view's items, but returns "" when there are no items.
+func topName(items []Item, skipUnknown bool) string {
+	if len(items) == 0 {
+		return ""
+	}
+	top := make([]types.StatItem, 0, 2) // topItemName looks at the first two at most
+	for _, item := range items[:min(2, len(items))] {
+		top = append(top, types.StatItem{Name: item.Name, TotalSeconds: item.Seconds})
+	}
+	return topItemName(top, skipUnknown)
+}
+
+// DatePart strips the time from an API timestamp ("2024-01-02T00:00:00Z" -> "2024-01-02").
+func DatePart(s string) string {
+	return strings.Split(s, "T")[0]
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+)
+
+func TestViewActiveDays(t *testing.T) {
+	stats := &types.StatsResponse{}
+	stats.Data.DaysIncludingHolidays = 7
+	if v := NewStatsView(stats, "last_7_days"); v.ActiveDays != nil {
+		t.Errorf("stats without days_minus_holidays: ActiveDays = %d, want nil", *v.ActiveDays)
+	}
+	active := 0
+	stats.Data.DaysMinusHolidays = &active
+	if v := NewStatsView(stats, "last_7_days"); v.ActiveDays == nil || *v.ActiveDays != 0 {
+		t.Errorf("stats with 0 active days: ActiveDays = %v, want 0", v.ActiveDays)
+	}
+
+	summary := &types.SummaryResponse{Data: daysOf(60, 0, 120)}
+	if v := NewSummaryView(summary, "Last 3 days"); v.ActiveDays == nil || *v.ActiveDays != 2 {
+		t.Errorf("summary: ActiveDays = %v, want 2", v.ActiveDays)
+	}
+}
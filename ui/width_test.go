@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{
+		"":              0,
+		"wakafetch":     9,
+		"Café":          4,
+		"Cafe\u0301":    4, // combining acute accent
+		"日本語":           6,
+		"한국어":           6,
+		"ｗａｋａ":          8, // fullwidth
+		"🌱 Go":          5,
+		"✅":             2,
+		"✓":             1,
+		"❤\ufe0f":       1, // the variation selector adds nothing
+		"─▆█■":          4,
+		"プロジェクト-x":      14,
+		"\U00020000":    2, // CJK extension B
+		"zero\u200bgap": 7,
+	}
+	for s, want := range tests {
+		if got := displayWidth(s); got != want {
+			t.Errorf("displayWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestSVGTextLength(t *testing.T) {
+	tests := map[string]string{
+		"Go":    `textLength="16.8"`,
+		"日本":    `textLength="33.6"`,
+		"🌱 app": `textLength="50.4"`,
+		"é":    `textLength="8.4"`,
+	}
+	for s, want := range tests {
+		cv := &svgCanvas{}
+		cv.text(0, 0, s, "#000000", false)
+		if !strings.Contains(cv.sb.String(), want) {
+			t.Errorf("text(%q) = %s, want %s", s, cv.sb.String(), want)
+		}
+	}
+
+	// a wide rune shifts the rest of its line by two cells, like in the terminal
+	screen := &Screen{lines: [][]cell{parseANSI("日 x")}, cols: 4}
+	cv := &svgCanvas{}
+	drawScreen(cv, screen, Themes["dark"], svgMetrics)
+	if got := cv.sb.String(); !strings.Contains(got, `x="41.2"`) || !strings.Contains(got, `>x</text>`) {
+		t.Errorf("the x after a wide rune isn't at its terminal column:\n%s", got)
+	}
+}
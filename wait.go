@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/types"
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+// waitInterval is how often --wait refetches stats that WakaTime is still computing.
+const waitInterval = 5 * time.Second
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// waitForStats refetches the /stats of rangeStr every waitInterval until WakaTime is done
+// computing them (--wait), showing how far it got on stderr. When --deadline runs out first,
+// or WakaTime reports them as stuck, the last (partial) stats are returned, and outputStats
+// warns about them.
+func waitForStats(ctx context.Context, config Config, apiKey, apiURL, rangeStr string, data *types.StatsResponse) (*types.StatsResponse, error) {
+	stderrInfo, err := os.Stderr.Stat()
+	tty := err == nil && stderrInfo.Mode()&os.ModeCharDevice != 0
+	lastPercent := -1
+	show := func(frame int) {
+		line := fmt.Sprintf("WakaTime is computing your stats: %d%% done", data.Data.PercentCalculated)
+		switch {
+		case tty:
+			fmt.Fprintf(os.Stderr, "\r%s%c %s%s"+clearLine, ui.Clr.Yellow, spinnerFrames[frame%len(spinnerFrames)], line, ui.Clr.Reset)
+		case data.Data.PercentCalculated != lastPercent:
+			fmt.Fprintln(os.Stderr, line)
+		}
+		lastPercent = data.Data.PercentCalculated
+	}
+	if tty {
+		defer fmt.Fprint(os.Stderr, "\r"+clearLine)
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	next := time.Now().Add(waitInterval)
+	for frame := 0; data.StillComputing() && !data.Data.IsStuck; frame++ {
+		show(frame)
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return data, nil
+			}
+			return nil, errInterrupted
+		case <-ticker.C:
+		}
+		if time.Now().Before(next) {
+			continue
+		}
+		latest, err := fetchStats(ctx, apiKey, apiURL, rangeStr, *config.timeoutFlag)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return data, nil
+			}
+			return nil, err
+		}
+		data, next = latest, time.Now().Add(waitInterval)
+	}
+	return data, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/andatoshiki/wakafetch/wakafetch/ui"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l" // alternate screen, hide cursor
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	cursorHome     = "\x1b[H"
+	clearLine      = "\x1b[K" // to the end of the line
+	clearBelow     = "\x1b[J"
+	maxWatchDelay  = 15 * time.Minute
+)
+
+// runWatch refetches the default view every --watch interval and redraws it in place
+// on the alternate screen, with a status line counting down to the next refresh.
+// Fetch errors don't exit: the last view stays up and the retries back off.
+func runWatch(ctx context.Context, config Config, apiKey, apiURL string) {
+	interval := config.watchFlag.interval
+
+	fmt.Print(enterAltScreen)
+	restore := func() { fmt.Print(leaveAltScreen) }
+	defer restore()
+
+	resize := make(chan os.Signal, 1)
+	if len(resizeSignals) > 0 {
+		signal.Notify(resize, resizeSignals...)
+	}
+
+	var (
+		draw      func() // prints the last fetched view
+		frame     string // draw's output for the current terminal size
+		updated   time.Time
+		fetchErr  error
+		retry     time.Duration // the delay before the next retry, doubling with each failure
+		nextFetch time.Time
+	)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		if !time.Now().Before(nextFetch) {
+			fetchCtx, cancel := withDeadline(ctx, config)
+			d, err := fetchWatchView(fetchCtx, config, apiKey, apiURL)
+			cancel()
+			if ctx.Err() != nil {
+				return // interrupted while fetching
+			}
+			fetchErr = err
+			if err != nil {
+				// capped before doubling again, so it can't overflow however long it fails
+				retry = min(max(interval, retry*2), max(interval, maxWatchDelay))
+				nextFetch = time.Now().Add(retry)
+			} else {
+				retry = 0
+				draw, updated = d, time.Now()
+				frame = ui.CaptureText(draw)
+				nextFetch = updated.Add(interval)
+			}
+		}
+
+		writeWatchFrame(frame, watchStatusLine(updated, nextFetch, fetchErr))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-resize:
+			if draw != nil {
+				frame = ui.CaptureText(draw) // relayout for the new width
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchWatchView fetches the data of the default view, returning a func that prints it.
+func fetchWatchView(ctx context.Context, config Config, apiKey, apiURL string) (func(), error) {
+	if shouldUseSummaryAPI(config, apiURL) {
+		data, heading, err := fetchSummaryFlow(ctx, config, apiKey, apiURL)
+		if err != nil {
+			return nil, err
+		}
+		return func() { outputSummary(config, data, heading) }, nil
+	}
+	data, rangeStr, err := fetchStatsFlow(ctx, config, apiKey, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	return func() { outputStats(config, data, rangeStr) }, nil
+}
+
+func watchStatusLine(updated, nextFetch time.Time, fetchErr error) string {
+	countdown := time.Until(nextFetch).Round(time.Second)
+	if fetchErr != nil {
+		line := ui.Clr.Red + fetchErr.Error() + ui.Clr.Reset + fmt.Sprintf(" · retrying in %s", countdown)
+		if !updated.IsZero() {
+			line += " · last updated " + updated.Format("15:04:05")
+		}
+		return line
+	}
+	return ui.Clr.MidGray + fmt.Sprintf("Last updated %s · refreshing in %s · Ctrl+C to quit", updated.Format("15:04:05"), countdown) + ui.Clr.Reset
+}
+
+// writeWatchFrame redraws the screen in one write: every line overwrites
+// the previous frame's and clears what's left of it, so nothing flickers.
+func writeWatchFrame(frame, status string) {
+	var sb strings.Builder
+	sb.WriteString(cursorHome)
+	for _, line := range strings.Split(strings.TrimRight(frame, "\n"), "\n") {
+		sb.WriteString(line + clearLine + "\n")
+	}
+	sb.WriteString("\n" + status + clearLine + clearBelow)
+	fmt.Print(sb.String())
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// resizeSignals is empty where there's no SIGWINCH: --watch picks up the new
+// width on its next redraw after a refetch.
+var resizeSignals []os.Signal
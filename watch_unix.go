@@ -0,0 +1,11 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// resizeSignals tell --watch that the terminal was resized.
+var resizeSignals = []os.Signal{syscall.SIGWINCH}